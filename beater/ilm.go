@@ -0,0 +1,62 @@
+package beater
+
+import (
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/idxmgmt/ilm"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// ILMPolicy is the index lifecycle policy installed by default. Indices are
+// rolled over weekly or when they reach 50GB, and deleted after 90 days.
+var ILMPolicy = common.MapStr{
+	"policy": common.MapStr{
+		"phases": common.MapStr{
+			"hot": common.MapStr{
+				"actions": common.MapStr{
+					"rollover": common.MapStr{
+						"max_size": "50gb",
+						"max_age":  "7d",
+					},
+				},
+			},
+			"delete": common.MapStr{
+				"min_age": "90d",
+				"actions": common.MapStr{
+					"delete": common.MapStr{},
+				},
+			},
+		},
+	},
+}
+
+// ILMSupport is the libbeat ILM support with ILMPolicy as the default
+// policy. A policy configured with setup.ilm.policy_file takes precedence.
+func ILMSupport(log *logp.Logger, info beat.Info, cfg *common.Config) (ilm.Supporter, error) {
+	s, err := ilm.DefaultSupport(log, info, cfg)
+	if err != nil || s.Mode() == ilm.ModeDisabled {
+		return s, err
+	}
+
+	config := struct {
+		PolicyFile  string `config:"policy_file"`
+		CheckExists bool   `config:"check_exists"`
+		Overwrite   bool   `config:"overwrite"`
+	}{CheckExists: true}
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+	if config.PolicyFile != "" {
+		return s, nil
+	}
+
+	if log == nil {
+		log = logp.NewLogger("ilm")
+	} else {
+		log = log.Named("ilm")
+	}
+	policy := ilm.Policy{Name: s.Policy().Name, Body: ILMPolicy}
+	return ilm.NewDefaultSupport(log, s.Mode(), s.Alias(), policy, config.Overwrite, config.CheckExists), nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/version"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func genCollectCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "collect",
+		Short: "Run the enabled metricsets against the configured servers and print the events as JSON",
+		Run: func(cmd *cobra.Command, args []string) {
+			once, _ := cmd.Flags().GetBool("once")
+			interval, _ := cmd.Flags().GetDuration("baseline-interval")
+			pretty, _ := cmd.Flags().GetBool("pretty")
+			if !once {
+				fmt.Fprintf(os.Stderr, "Only --once is supported, use run to collect continuously\n")
+				os.Exit(1)
+			}
+
+			hosts, closeHosts, err := loadHosts()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+
+			var metricsets []mb.MetricSet
+			for _, h := range hosts {
+				metricsets = append(metricsets, h.metricsets...)
+			}
+			failed := collectOnce(metricsets, interval, json.New(version.GetDefaultVersion(), json.Config{Pretty: pretty}))
+			closeHosts()
+
+			if failed > 0 {
+				fmt.Fprintf(os.Stderr, "%d of %d metricsets failed\n", failed, len(metricsets))
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().Bool("once", false, "Fetch every metricset once and exit")
+	command.Flags().Duration("baseline-interval", 5*time.Second,
+		"Time between the baseline and the reported fetch of the metricsets computing values over an interval, 0 reports them without a baseline")
+	command.Flags().Bool("pretty", false, "Indent the JSON output")
+
+	return command
+}
+
+// collectOnce fetches every metricset once and prints the events, one JSON
+// document per line. The metricsets computing values over an interval are
+// fetched a first time to take a baseline, and reported after interval. It
+// returns the number of metricsets that failed.
+func collectOnce(metricsets []mb.MetricSet, interval time.Duration, enc *json.Encoder) int {
+	if interval > 0 {
+		baseline := false
+		for _, ms := range metricsets {
+			if b, ok := ms.(mssql.Baseliner); ok && b.NeedsBaseline() {
+				fetchOnce(ms)
+				baseline = true
+			}
+		}
+		if baseline {
+			time.Sleep(interval)
+		}
+	}
+
+	failed := 0
+	for _, ms := range metricsets {
+		r := fetchOnce(ms)
+		if r.failed {
+			failed++
+		}
+		if p, ok := ms.(permissionsChecker); ok && len(p.PermissionsMissing()) > 0 {
+			fmt.Fprintf(os.Stderr, "Metricset %s/%s is disabled on %s, the login lacks %s\n",
+				ms.Module().Name(), ms.Name(), ms.Host(), strings.Join(p.PermissionsMissing(), ", "))
+			failed++
+		}
+		for i := range r.events {
+			data, err := enc.Encode(settings.Name, &r.events[i])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to encode an event of %s/%s: %v\n", ms.Module().Name(), ms.Name(), err)
+				failed++
+				continue
+			}
+			os.Stdout.Write(append(data, '\n'))
+		}
+	}
+	return failed
+}
+
+// permissionsChecker is implemented by the metricsets that do not fetch when
+// the login lacks permissions.
+type permissionsChecker interface {
+	PermissionsMissing() []string
+}
+
+func fetchOnce(ms mb.MetricSet) *collectReporter {
+	r := &collectReporter{ms: ms, start: time.Now()}
+	switch fetcher := ms.(type) {
+	case mb.ReportingMetricSetV2:
+		fetcher.Fetch(r)
+	default:
+		r.Error(fmt.Errorf("metricset %s/%s does not support collect", ms.Module().Name(), ms.Name()))
+	}
+	return r
+}
+
+// collectReporter keeps the events of a fetch, with the metricset information
+// the Metricbeat framework adds to them.
+type collectReporter struct {
+	ms     mb.MetricSet
+	start  time.Time
+	events []beat.Event
+	failed bool
+}
+
+func (r *collectReporter) Event(event mb.Event) bool {
+	if event.Error != nil {
+		r.failed = true
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if event.Host == "" {
+		event.Host = r.ms.Host()
+	}
+	if event.Took == 0 {
+		event.Took = time.Since(r.start)
+	}
+
+	r.events = append(r.events, event.BeatEvent(r.ms.Module().Name(), r.ms.Name(), mb.AddMetricSetInfo))
+	return true
+}
+
+func (r *collectReporter) Error(err error) bool {
+	return r.Event(mb.Event{Error: err})
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func genTestConnectionCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "connection",
+		Short: "Test " + Name + " can connect to the configured servers and has the permissions the metricsets need",
+		Run: func(cmd *cobra.Command, args []string) {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+
+			hosts, closeHosts, err := loadHosts()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			defer closeHosts()
+
+			t := newCheckTable(os.Stdout)
+			for _, h := range hosts {
+				testConnection(t, h, timeout)
+			}
+			t.Flush()
+
+			if t.failed {
+				closeHosts()
+				os.Exit(1)
+			}
+		},
+	}
+	command.Flags().Duration("timeout", 10*time.Second, "Timeout of the checks of each server")
+
+	return command
+}
+
+// testConnection checks that the host accepts the login, then that it has
+// the permissions of every enabled metricset.
+func testConnection(t *checkTable, h *host, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	server := h.name()
+	db, err := mssql.NewConnection(h.data)
+	if err != nil {
+		t.fail(server, "connect", err.Error())
+		return
+	}
+	defer db.Close()
+
+	info, err := mssql.CheckConnection(ctx, h.data, db)
+	if len(info.Addresses) > 0 {
+		t.pass(server, "resolve", strings.Join(info.Addresses, ", "))
+	}
+	if err != nil {
+		class := mssql.ErrorClass(ctx, err)
+		check := "connect"
+		if class == mssql.ErrorClassDNS && len(info.Addresses) == 0 {
+			check = "resolve"
+		}
+		t.fail(server, check, fmt.Sprintf("%s error: %v", class, err))
+		return
+	}
+
+	connection := fmt.Sprintf("%s, login took %v", info.Transport, info.LoginDuration.Round(time.Millisecond))
+	if info.Port != 0 {
+		connection = fmt.Sprintf("%s port %d, login took %v", info.Transport, info.Port, info.LoginDuration.Round(time.Millisecond))
+	}
+	if info.Instance != "" {
+		connection = fmt.Sprintf("instance %s on %s", info.Instance, connection)
+	}
+	t.pass(server, "connect", connection)
+
+	if info.Encryption != "" {
+		t.pass(server, "tls", info.Encryption)
+	} else {
+		t.warn(server, "tls", "unknown, requires VIEW SERVER STATE or the encrypt setting")
+	}
+	if info.AuthScheme != "" {
+		t.pass(server, "auth", info.AuthScheme)
+	} else {
+		t.warn(server, "auth", "unknown")
+	}
+	t.pass(server, "version", fmt.Sprintf("%s %s (%s)", info.ServerName, info.Version, info.Edition))
+
+	for _, ms := range h.metricsets {
+		for _, p := range mssql.RequiredPermissions(ms.Name()) {
+			check := ms.Name() + ": " + p.Name
+			granted, err := mssql.HasPermission(ctx, mssql.DBQuerier{DB: db}, p)
+			switch {
+			case err != nil:
+				t.fail(server, check, err.Error())
+			case !granted:
+				t.fail(server, check, "not granted")
+			default:
+				t.pass(server, check, "granted")
+			}
+		}
+	}
+}
+
+// checkTable prints the checks as a table, and records whether any failed.
+// A check that could not be determined is a warning, it does not fail.
+type checkTable struct {
+	w      *tabwriter.Writer
+	failed bool
+}
+
+func newCheckTable(out io.Writer) *checkTable {
+	t := &checkTable{w: tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)}
+	fmt.Fprintln(t.w, "SERVER\tCHECK\tRESULT\tDETAIL")
+	return t
+}
+
+func (t *checkTable) pass(server, check, detail string) {
+	fmt.Fprintf(t.w, "%s\t%s\tOK\t%s\n", server, check, detail)
+}
+
+func (t *checkTable) warn(server, check, detail string) {
+	fmt.Fprintf(t.w, "%s\t%s\tWARN\t%s\n", server, check, detail)
+}
+
+func (t *checkTable) fail(server, check, detail string) {
+	t.failed = true
+	fmt.Fprintf(t.w, "%s\t%s\tFAIL\t%s\n", server, check, detail)
+}
+
+func (t *checkTable) Flush() error {
+	return t.w.Flush()
+}
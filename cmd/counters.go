@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/module/mssql/performance"
+)
+
+func genCountersCmd() *cobra.Command {
+	countersCmd := &cobra.Command{
+		Use:   "counters",
+		Short: "Explore the performance counters of the configured servers",
+	}
+	countersCmd.AddCommand(genCountersListCmd())
+
+	return countersCmd
+}
+
+func genCountersListCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List the performance counters exposed by the configured servers and how they are collected",
+		Run: func(cmd *cobra.Command, args []string) {
+			object, _ := cmd.Flags().GetString("object")
+			counter, _ := cmd.Flags().GetString("counter")
+			includeConfig, _ := cmd.Flags().GetBool("include-config")
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+
+			hosts, closeHosts, err := loadHosts()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			defer closeHosts()
+
+			var all []performance.CounterInfo
+			for _, h := range hosts {
+				infos, err := listCounters(h, timeout)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to list the counters of %s: %v\n", h.name(), err)
+					closeHosts()
+					os.Exit(1)
+				}
+				infos = filterCounters(infos, object, counter)
+
+				if includeConfig {
+					for _, info := range infos {
+						if !info.Included {
+							all = append(all, info)
+						}
+					}
+					continue
+				}
+				fmt.Printf("Server %s:\n\n", h.name())
+				printCounters(infos)
+				fmt.Println()
+			}
+
+			if includeConfig {
+				fmt.Print(performance.IncludeConfig(all))
+			}
+		},
+	}
+	command.Flags().String("object", "", "Only list the counters of the objects containing this text")
+	command.Flags().String("counter", "", "Only list the counters whose name contains this text")
+	command.Flags().Bool("include-config", false, "Print the configuration including the listed counters that are not collected yet")
+	command.Flags().Duration("timeout", 10*time.Second, "Timeout of the query of each server")
+
+	return command
+}
+
+// listCounters lists the counters of a host, marking those collected by its
+// performance metricset.
+func listCounters(h *host, timeout time.Duration) ([]performance.CounterInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var perf *performance.MetricSet
+	for _, ms := range h.metricsets {
+		if p, ok := ms.(*performance.MetricSet); ok {
+			perf = p
+		}
+	}
+
+	db, err := mssql.NewConnection(h.data)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return performance.ListCounters(ctx, mssql.DBQuerier{DB: db}, perf)
+}
+
+func filterCounters(infos []performance.CounterInfo, object, counter string) []performance.CounterInfo {
+	object, counter = strings.ToLower(object), strings.ToLower(counter)
+	var filtered []performance.CounterInfo
+	for _, info := range infos {
+		if strings.Contains(strings.ToLower(info.Object), object) && strings.Contains(strings.ToLower(info.Name), counter) {
+			filtered = append(filtered, info)
+		}
+	}
+	return filtered
+}
+
+// printCounters prints the counters as a table, the object is only given on
+// the first counter of each object.
+func printCounters(infos []performance.CounterInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "OBJECT\tCOUNTER\tINSTANCES\tTYPE\tCOMPUTATION\tFIELD\tINCLUDED")
+
+	object := ""
+	for _, info := range infos {
+		shownObject := ""
+		if info.Object != object {
+			object = info.Object
+			shownObject = object
+		}
+
+		field, included := "-", "-"
+		if info.Reported() {
+			field = info.Field()
+			included = "no"
+			if info.Included {
+				included = "yes"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			shownObject, info.Name, len(info.Instances), info.TypeName(), info.Computation(), field, included)
+	}
+	w.Flush()
+}
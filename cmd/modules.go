@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/config"
+)
+
+// host is a configured server and the metricsets enabled for it.
+type host struct {
+	data       mb.HostData
+	metricsets []mb.MetricSet
+}
+
+// loadHosts reads the configuration file given on the command line and
+// creates the metricsets of the enabled mssqlbeat.modules, grouped by host in
+// configuration order. The returned function closes the metricsets.
+func loadHosts() ([]*host, func(), error) {
+	b, err := instance.NewBeat(settings.Name, settings.IndexPrefix, settings.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error initializing beat: %v", err)
+	}
+	if err := b.InitWithSettings(settings); err != nil {
+		return nil, nil, fmt.Errorf("error initializing beat: %v", err)
+	}
+
+	cfg, err := b.BeatConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := config.DefaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, nil, fmt.Errorf("error reading config file: %v", err)
+	}
+
+	var hosts []*host
+	var all []mb.MetricSet
+	byURI := map[string]*host{}
+	closeAll := func() {
+		for _, ms := range all {
+			if closer, ok := ms.(mb.Closer); ok {
+				closer.Close()
+			}
+		}
+	}
+
+	for _, modCfg := range c.Modules {
+		_, metricsets, err := mb.NewModule(modCfg, mb.Registry)
+		if err == mb.ErrModuleDisabled {
+			continue
+		}
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+
+		for _, ms := range metricsets {
+			all = append(all, ms)
+			data := ms.HostData()
+			h, found := byURI[data.URI]
+			if !found {
+				h = &host{data: data}
+				byURI[data.URI] = h
+				hosts = append(hosts, h)
+			}
+			h.metricsets = append(h.metricsets, ms)
+		}
+	}
+
+	if len(hosts) == 0 {
+		closeAll()
+		return nil, nil, fmt.Errorf("no host configured in %s.modules", settings.Name)
+	}
+	return hosts, closeAll, nil
+}
+
+// name identifies the host in the output of the commands: the address and
+// the named instance, without the credentials and parameters of the URL.
+func (h *host) name() string {
+	name := h.data.Host
+	if u, err := url.Parse(h.data.URI); err == nil && len(u.Path) > 1 {
+		name += u.Path
+	}
+	return name
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func genExportPermissionsCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "permissions",
+		Short: "Export the T-SQL creating a login with the permissions of the enabled metricsets",
+		Run: func(cmd *cobra.Command, args []string) {
+			login, _ := cmd.Flags().GetString("login")
+			windows, _ := cmd.Flags().GetBool("windows")
+
+			hosts, closeHosts, err := loadHosts()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			defer closeHosts()
+
+			var names []string
+			var required []mssql.Permission
+			seen := map[string]bool{}
+			for _, h := range hosts {
+				if login == "" {
+					login = h.data.User
+				}
+				for _, ms := range h.metricsets {
+					if seen[ms.Name()] {
+						continue
+					}
+					seen[ms.Name()] = true
+					names = append(names, ms.Name())
+					required = append(required, mssql.RequiredPermissions(ms.Name())...)
+				}
+			}
+			if login == "" {
+				login = Name
+			}
+			sort.Strings(names)
+
+			fmt.Print(mssql.GrantScript(login, windows, names, required))
+		},
+	}
+	command.Flags().String("login", "", "Name of the login, defaults to the username of the first configured host")
+	command.Flags().Bool("windows", false, "Create the login from a Windows account")
+
+	return command
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/version"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func genReplayCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "replay FILE...",
+		Short: "Compute the events of a metricset from recorded result sets and print them as JSON",
+		Long: `Replay feeds the result sets recorded by a metricset, with recording.enabled
+in the module configuration, back through the metricset and prints the events
+of every recorded fetch as JSON, with the time they were recorded.
+
+The rotated files of a recording are replayed first, oldest first. The
+metricset is named by the file, its configuration is read from the
+configuration file when it has the same host and metricset, the default
+configuration is used otherwise.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			name, _ := cmd.Flags().GetString("metricset")
+			pretty, _ := cmd.Flags().GetBool("pretty")
+
+			var files []string
+			for _, path := range args {
+				files = append(files, recordingFiles(path)...)
+			}
+			metricset, recording := mssql.RecordingMetricSet(files[0])
+			if name != "" {
+				metricset = name
+			}
+
+			ms, closeMetricSets, err := replayMetricSet(metricset, recording)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			defer closeMetricSets()
+
+			failed, err := replay(ms, files, json.New(version.GetDefaultVersion(), json.Config{Pretty: pretty}))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				closeMetricSets()
+				os.Exit(1)
+			}
+			if failed > 0 {
+				fmt.Fprintf(os.Stderr, "%d fetches failed\n", failed)
+			}
+		},
+	}
+	command.Flags().String("metricset", "", "Metricset replaying the recording, named by the file by default")
+	command.Flags().Bool("pretty", false, "Indent the JSON output")
+
+	return command
+}
+
+// recordingFiles returns the rotated files of a recording, oldest first,
+// followed by the recording. The rotated files of a path ending in a rotation
+// number are not added.
+func recordingFiles(path string) []string {
+	if _, name := mssql.RecordingMetricSet(path); filepath.Base(path) != name {
+		return []string{path}
+	}
+
+	var files []string
+	for n := 1; ; n++ {
+		backup := path + "." + strconv.Itoa(n)
+		if _, err := os.Stat(backup); err != nil {
+			break
+		}
+		files = append([]string{backup}, files...)
+	}
+	return append(files, path)
+}
+
+// replayMetricSet returns the configured metricset that recorded the file, or
+// a metricset with the default configuration.
+func replayMetricSet(metricset, recording string) (mb.MetricSet, func(), error) {
+	hosts, closeHosts, err := loadHosts()
+	if err == nil {
+		for _, h := range hosts {
+			for _, ms := range h.metricsets {
+				if r, ok := ms.(recorder); ok && ms.Name() == metricset && r.RecordingName() == recording {
+					return ms, closeHosts, nil
+				}
+			}
+		}
+		closeHosts()
+	}
+	fmt.Fprintf(os.Stderr, "Replaying %s with the default configuration of the %s metricset\n", recording, metricset)
+
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"module":     "mssql",
+		"metricsets": []string{metricset},
+		"hosts":      []string{"sqlserver://replay"},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	_, metricsets, err := mb.NewModule(cfg, mb.Registry)
+	if err != nil {
+		return nil, nil, err
+	}
+	closeAll := func() {
+		for _, ms := range metricsets {
+			if closer, ok := ms.(mb.Closer); ok {
+				closer.Close()
+			}
+		}
+	}
+	return metricsets[0], closeAll, nil
+}
+
+// recorder is implemented by the metricsets that can record and replay their
+// result sets.
+type recorder interface {
+	RecordingName() string
+	Replay(paths ...string) error
+	Querier() mssql.Querier
+}
+
+// replay fetches the metricset once per recorded fetch and prints the events.
+// It returns the number of fetches that failed.
+func replay(ms mb.MetricSet, files []string, enc *json.Encoder) (int, error) {
+	r, ok := ms.(recorder)
+	if !ok {
+		return 0, fmt.Errorf("metricset %s/%s does not support replay", ms.Module().Name(), ms.Name())
+	}
+	if err := r.Replay(files...); err != nil {
+		return 0, err
+	}
+	q := r.Querier().(*mssql.ReplayQuerier)
+
+	failed := 0
+	for i, runs := 0, q.Runs(); i < runs; i++ {
+		result := fetchOnce(ms)
+		if result.failed {
+			failed++
+		}
+		for j := range result.events {
+			if t := q.Time(); !t.IsZero() {
+				result.events[j].Timestamp = t
+			}
+			data, err := enc.Encode(settings.Name, &result.events[j])
+			if err != nil {
+				return failed, fmt.Errorf("failed to encode an event of %s/%s: %v", ms.Module().Name(), ms.Name(), err)
+			}
+			os.Stdout.Write(append(data, '\n'))
+		}
+	}
+	return failed, nil
+}
@@ -10,5 +10,18 @@ import (
 // Name of this beat
 var Name = "mssqlbeat"
 
+var settings = instance.Settings{
+	Name: Name,
+	ILM:  beater.ILMSupport,
+}
+
 // RootCmd to handle beats cli
-var RootCmd = cmd.GenRootCmdWithSettings(beater.New, instance.Settings{Name: Name})
+var RootCmd = cmd.GenRootCmdWithSettings(beater.New, settings)
+
+func init() {
+	RootCmd.AddCommand(genCollectCmd())
+	RootCmd.AddCommand(genCountersCmd())
+	RootCmd.AddCommand(genReplayCmd())
+	RootCmd.ExportCmd.AddCommand(genExportPermissionsCmd())
+	RootCmd.TestCmd.AddCommand(genTestConnectionCmd())
+}
@@ -3,19 +3,26 @@
 
 package config
 
-import "time"
+import (
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/mathenning/mssqlbeat/prometheus"
+)
 
 type Config struct {
-	Period   time.Duration `config:"period"`
-	Username string        `config:"username"`
-	Password string        `config:"password"`
-	Host     string        `config:"host"`
-	Instance string        `config:"instance"`
-	Port     int           `config:"port"`
+	// Modules is the list of mssql module configurations, each of them
+	// defining the servers to collect from and the metricsets to run.
+	Modules []*common.Config `config:"modules"`
+
+	// Prometheus configures the listener exposing the latest collected
+	// values in the Prometheus text format.
+	Prometheus prometheus.Config `config:"prometheus"`
+
+	// Host was the single server setting before the module based
+	// configuration. It is only kept to reject outdated configurations.
+	Host string `config:"host"`
 }
 
 var DefaultConfig = Config{
-	Period:   1 * time.Second,
-	Instance: "",
-	Port:     1433,
+	Prometheus: prometheus.DefaultConfig,
 }
@@ -32,5 +32,5 @@ func init() {
 // AssetFieldsYml returns asset data.
 // This is the base64 encoded gzipped contents of fields.yml.
 func AssetFieldsYml() string {
-	return "eJzsvXlzHDeSOPq/PwUeHfEo7Tabh6jD3Jjdx6FkmzE6uCK13tn1Bhtdhe6GWQWUARRb7Re/7/4LZCZQqKN5yGqNFMv5YyxWVwGJRCIzkef37Jfj929P3/70/7CXmintmMilY24hLZvJQrBcGpG5YjVi0rElt2wulDDciZxNV8wtBHt1cs4qo38TmRt99z2bcityphU8vxbGSq3Y/nhvvDf+7nt2VghuBbuWVjq2cK6yR7u7c+kW9XSc6XJXFNw6me2KzDKnma3nc2EdyxZczQU88sPOpChyO/7uux12JVZHTGT2O8acdIU48i98x1gubGZk5aRW8Ij9SN8w+vroO8Z2mOKlOGLb/5+TpbCOl9X2d4wxVohrURyxTBsBfxvxey2NyI+YMzU+cqtKHLGcO/yzNd/2S+7Erh+TLRdCAZrEtVCOaSPnUnn0jb+D7xi78LiWFl7K43fiozM882ieGV02I4z8xDLjRbFiRlRGWKGcVHOYiEZsphvcMKtrk4k4/+ks+QB/YwtumdIB2oJF9IyQNK55UQsAOgJT6aou/DQ0LE02k8Y6+L4DlhGZkNcNVJWsRCFVA9d7wjnuF5tpw3hR4Ah2jPskPvKy8pu+fbC3/2xn7+nOwZOLvRdHe0+PnhyOXzx98l/byTYXfCoKO7jBuJt66qkYHuA/L/H5lVgttckHNvqktk6X/oVdxEnFpbFxDSdcsalgtT8STjOe56wUjjOpZtqU3A/in9Oa2PlC10UOxzDTynGpmBLWbx2CA+Tr/3dcFLgHlnEjmHXaI4rbAGkE4FVA0CTX2ZUwE8ZVziZXL+yE0NHBJH3Hq6qQGcdVzrTemXJDPwl1feQPfF5n/ucEv6Wwls/FDQh24qMbwOKP2rBCzwkPQA40Fm0+YQN/8m/SzyOmKydL+UckO08m11Is/ZGQinF42z8QJiLFT2edqTNXe7QVem7ZUrqFrh3jqqH6Fgwjpt1CGOIeLMOdzbTKuBMqIXynPRAl42xRl1ztGMFzPi0Es3VZcrNiOjlw6Sks68LJqohrt0x8lNaf+IVYNROWU6lEzqRymmkV3+6eiJ9FUWj2izZFnmyR4/ObDkBK6HKutBGXfKqvxRHb3zs47O/ca2mdXw99ZyOlOz5ngmeLsMr2Yf3vrYZ+tkZsS6jrg63/SY8qnwuFlEJc/Tg+mBtdV0fsYICOLhYCv4y7RKeIeCtnfOo3GbngzC394fH803n5Ngu0r1Ye59wfwqLwx27EcuHwH9owPbXCXPvtQXLVnswW2u+UNszxK2FZKbitjSj9CzRsfK17OC2TKivqXLC/Cu7ZAKzVspKvGC+sZqZW/mua19gxCDRY6PifaKk0pF14HjkVDTsGyvbwc1nYQHuIJFMr5c+JRgR52JL1hfO+XAiTMu8FryrhKdAvFk5qXCowdo8ARdQ409op7fyeh8UesVOcLvOKgJ7houHc+oM4auAbe1JgpIhMBXfj5Pwen70BlYQEZ3tBtOO8qnb9UmQmxqyhjZT55loE1AHXBT2DyRlSi7TMi1fmFkbX8wX7vRa1H9+urBOlZYW8EuxvfHbFR+y9yCXSR2V0JqyVah42hV63dbbwTPq1nlvH7YLhOtg5oJtQhgcRiBxRGLWV5nSIaiFKYXhxKQPXofMsPjqh8oYX9U712nPdPUuvwhxM5v6IzKQwSD7SEiIfyRlwIGBT9nGk66DTeElmStAOggLHM6OtF/7WcePP07R2bILbLfMJ7IffCUJGwjRe8MPZ0729WQsR3eVHdvanlv5Byd+9enP/dUdx60kUCRu+W4JcnwoGZCzztcvLW8vz/7+JBZLWAucr5Qi9HbSM41vIDlEEzeW1ALWFK/oM36afF6KoZnXhD5E/1LTCOLBbavYjHWgmlXVcZaTGdPiR9RMDU/JEQuKUNeJUVNxwUkFo+ZYpIXK8fywXMlv0p4onO9Oln8yr18m6T2de8Q2cB5aKLCk80jMnFCvEzDFRVm7V38qZ1q1d9Bu1iV28WFU3bF/gdn4CZh1fWcaLpf9PxK1XBe0ikCZuK2nj+K2X5uMGNSry7IjV5l0kcZpiKppXQITJWWvjmx3rEkBr80ueLfyVoI/idJyAZ7psbgDV/0HX2DayOzA983fcHZMdJGpMVsiOHnPSPLlBkTmmLz3B5WIGCh/HnZNKOsmdBqbEmRJuqc2V13SUAIXKn7oAGyooRsy5yUFwebmklR0l76PQmkq86UvtNd9ZoZf+huZ1upbafHFyRqPiqWjA7MHmH/jXE8iAi1ihorri3zn/+1tW8exKuEf28RhmQU27MtrpTBe9qfBG68VKa9KgZxm4rgt/KQqaQMCSM1xZDsCM2bkuRZTNtUUdxwlTsq1wTddmq9HqjZgJ0wJFdRZoUc2gn0kHxZ2diqiDgQ6aIABBYB4sNQ/b3EyRwo/aNBFRmMCfnNrWHiE0aqP8SeXB+61WuAGgC6J2F4wobGC0BsFKu96Ynqvjhu3AIQvX13jpxfF2w0TRTAHMGuWEvwlbUXLlZAZauvjoSKSIj6gsjJCDfxdZexAsTrNr6dcr/xCNZu9XKgxo+1a6mtN+nM7YStcmzjHjRRGoT6og15yYa7Ma+VcDR7ROFgUTyuu2RLhoG/FcMxfWefrwOPUIm8miiEoXryqjKyO5E8XqHlodz3MjrN2UQgfkjio8ERdNSMw38plyKue1rm2xQnKGbyLHXnq0WF0KsAmxwt8AuWKnZyPGWa5LvwHaMM5qJT8yqz2djBn7e4NZkhFgtGjUgoVghi8DTIHwJ2N6MEGUtUWc8jeARoLlNRot8Ao6Gctq4kGZjBGsib/GVULlpGOggqBVAwTcJ2jHwq5MV07YW2RKoaOuj1eL9metffir/wGvFdGyR/vh782eH+B1oCtf9l8ctgDDRW1A2tH5xfHHrTnnQo8z6VaXG9JMT6RbwVS91b/RyhnBiz44WjmphHKbgultoiXHyXrwvdXGLdhxKYzM+ACQtXJmdSmtvsx0vhHU4RTs9Pwd81P0IDw5XgvWpnaTQBrc0BOueN7HVKGzVKdfB85c6MtKy8iX2lYprebS1Tny6oI7+KMHwfb/z7YKrbaO2M7zJ+Nn+4cvnuyN2FbB3dYRO3w6frr39If9F+z/bPeA7OPr87HpD1aYncCLk59Q3QvoGTFSvlEC6xmbG67qghvpVilTXbHMM3fQORLmeRJ4ZrzaIIVLg9I0E8oJQ5rXrNDaMFWXU2FGoMovZKPX2DgoglewarGy0v8jmNaycKxtAsJb7RL3ARgOpWK8droEFj4XOqy2fwGYauu02smz3t4YMZdabfKkvYcZbjpoO/9+sg6uDR01gmnwpP17LaaijShZ3QJDfKFNnKdnUUAHjgjCIqUstAJoJbzsjTbt07PrQ//g9Oz6WaN4dGRtybMN4ObN8ck6qNPJUaW9h6hvTXKGX3+SYD9ow6GN+1QgtHE3LbG2woxFyWWxIe7lmReDCQLGBwCY1UUxcA4+KxDblvlpYFpgWfyay4JPi/7xOC6mwjj2SirrBClULXhBax9vzNLatzbOyLIOE0eDCNwSd6uCO69jDuAV4dwgYlNNCCfrA7HgdrEx0YiY8vMwP48/V5k2Rvh7acusP8MbiH/RyxSl1Sp1EqKanjCtD1aQyXICq5A53hzgD7+6SXQlZVrNcK940ZrT6xoZV82NmQXXb4fL0Qwb4HTvOky37pJWZIAAQx+qDUmn84VnTKhmgJtHqj4gyZHkcCRbdjRd45TRjBYerLeiYcQHQ/LIAxOGoRiYhmaGRzdw4+DC2zBah8OlDmzEbK1Da8beCGdkhoZmmxqyuWKvTg7QjO0pZCZcthAWtKxkdCadJR9iA6Snrrbru+XDlDYaSNsg0LimVuScNKLULppTma6dlblIZupChjBxRt6zsKCw6ar5lDTEtpceB20GAjchTR4EoR9W2gZUQth97CUZ3F82x5m3LxoE4VzgHjVzruQfeOhlHl3edMpWLJezmTCpzQT0YAmOXsbxeO44obhyTKhrabQq20pUQ1vHv5zHyWU+Yj9pPS8E0j979/4ndpqjUxpMpr0D39ecnz179vz58xcvXvzwww9tdKKElIW/3//RmEU+N1aPk3mYn8djBW0xQNNwVJpD1GMOtd0R3Lqd/Y5KS56EzZHDafAgnb4M3AtgDYewC6jc2T94cvj02fMXP+zxaZaL2d4wxBsU2RHm1NfXhzpRwOFh32X12SB6E/hA4r26EY3uYFyKXNZlW0s2+lrmMUhhk6oOcoAw4TgczjQAiy/tiPE/aiNGbJ5Vo3iQtWG5nEvHC50JrvqSbmlby8Jb4oYWRZfETzxuqThGRk/YDyK59fAG51Z8se3AIM9CLz4uCdmpRCZnMtwRIxRonicfFFnp9SwdJAm2FFaEeReiqBIFEuQVhq/GoS1JQrXyCHKyFPcQUBvR8UgJbhYv8/YZliWfb5SnpGcDJoumUQRoyS2b1rJwXpwPgOb4fEOQNZRFcPF5G4AkAvTm2ZNI0BtiQbvMFialsMrWvBvcjWbNjfEnchMk2U2xExydlVzxudfegJ9EOuhxEoxATdhI4kVLGcnLzuMbWEny6s3uVtSek7fBmoomn912JObAmImH9TbfKnIf8q1+jb6/luvyTg7ARo3F4O3P5ACMw4Ij8H+3AzDdlGAspCj9ziH6Yl7A9Bg8uAIfXIGfB6QHV+DdcfbgCnxwBX5LrsBEiH1r/sAW6GzDTsF7CPuNeAbXLvbBPfjgHnxwD7IH9+C35h7E/O9OBvhNhoM3wvGddHeCaZEyzHHKu1zcb0s6GMgc/3NpWUlWPeheFNGrYTGWOT1mE5HZMb00wSSeAEZD4eCx80RZ1tZhKhMchqIXz83YL/6m/XstzAoi1DGHK5KRVLnMhGU7O3SjLvkqAARJ/IWcL1wx5BhLVgPfU90BD1rhBadUTswNxY3z/DcPahCZ2UKUvIN/1kqutX1lEQoRpJRjjG5ZsV/FBzfnmTZW5AySkijEHQeEc8TVil1J1VgsPmCKQYlpUfgeWK4xo9IjrxDohvVoDtmlwKMyboVtUjHDsmDvpbOimDXeV65w9HuYnzakHgMyYfBwRUAzoSAA24roBq3lA9JzAII0f309GDGHfXCxIRs7pbHrTg7Qq+s75jLj/g55SUI6w7CjpNBBCUSHipFZi1YiSR5Denw7yciTT+ApnqD8liXpw2D5W+A+8iYbODDp100aPzCWkNoMuTWyFP6yGrxP/qkfKI7RZETrWbIIGi8MxUOGLYMk0hBoQeETTUoU6u5sKjDziVRwGpMHU63TjKcq8QiNlwN5VVPhlkL4mUL+hMopRiL6IXEySknCHOms0F7Is+OwE7ejGy9LNGSpjfA3bjAnFTAi5qvAn2miOQA0jOjkNRq2SdVuYT2llgblpSi1WTHP5CAfhobLE8Q3BHddF0oY9PDLJheeXrZeCRI5ZsLfJ9jjDqagTw7ywNFZxissCUFZkG3HACXFRmMHZZ81B1AmlV7G7BRckrB7jXax4IpN8IWQdTRpMizjRvizPgGE7PA8n4zYhEh+B0hewKOZLMROZoQntAmm6oS6LHHEmIAdKI5WJv08JVh2+kLSK107FbfWI3MHs7Ha4oJA38R2vMLDQDN0kR+F3ELOF5R+NswDgUOCAJ31diWOCbsD2W6dzUGCmIzCnlqhLKWBNYYqHsGMcDUjB+2Ih8zAX7jxhxvqH8xqiDmLqo+eeVVoxJaCVQUHswDFGzAehyyo2AbPMlE5yIGmEASUaUF1GrEKqyzVVqBXKuP1sO0Mdhr8dw1riJuMlHXLHscCSN19JCLHQXpRbMPVkTxPgoJBcc1GcKDZkGqOuaorzOnrlQwiIkEF0h9V6dl6RraXpshTzPxLHjXbSrDGMSNHHajJFGvFdFnFqWKlti7JRQQDqieipW7qKVl0p03FgJaMRzr8mTVeqqxdVSjjRQYuSbLuFHwVZRXgiSQdFYICFZ6EThOo0hIdsC3waaimYqwLUlfkTHZS/gMkpVayScRlyRDb26DJhh3zf4YQMKfZlRAVqyskVvgorUbVxiqkoAOkbTx6lolqXsaLUbqzjX9w4Ladc8etuM2s9kmcLLWH0DSdDP1MK3+U0Z4/oXcm7JHn7FY4tkvi2Ar32NNzsIxjZQmvPDBbTxvw4fpT6rwuhAVW1zp2KZ9EzcDvYG08rRWrUERKqmbS9MKPJNL8hNP4TSVo4eU+i7GOu3aMU16bu/h1BnyqnS+lqmp3GX5UXGkrMt1kl+vapS9w+0YWhRx8pzIikxb2bX9wM1/S1C1x4pGVTNsuI4EcAeQ1oA7/Fl5nNIJdKb1UaTG1hkrd8KkPRxpmV3h3x9GTsKR451B3sUeuY94NqD2+3WXZMKingvjcC7zr1PXkuXrBvezCwkKdeKUNmgR/5nbBHlXCLHhlobwQlN2ZSTUXpjJSucd+Pw1fksxw2m8AiFan4wJyUWplnfHLh/sSWCWkWw0Y7EPA59C/jv968vKLXXlPX/rVxGiYRJ3twDxYeeZK3omAPlnh9uMPF0IjGT6X1xAv3VXtlqSCdSP8EpIMNNsIt1Dcja6Cia3vBk2xo43D00kz5sQzNuH1cF5wU06+TgUPgGwbOYBvb1rekXRA7/CNBXew0FB6i2q9mYzWlX/axEpa/YWXK/t7O0IkqGqbWPp7vgS7UCwZqGfg8TaRmj6QinQDL1mjxCrt5UwuPgrk+bnOLpPQ41xaTyk5yntwMIA6KbjJFiJvCHZaOyZjESfjBbm4Drrs5BJ1rUkfk+eiYvs/sL0XRwfPjvb3MGD45NWPR3v/7/f7B4f/ci6y2i8A/2Ju4VV+vFMYfLY/plf39+gfzcnUpmS2zrxiOasLVEOqSuThA/yvNdlf9vegiOw+y637y8F4f3wwPrCV+8v+wZO2m1TXLtObi8rw7IumWMfBWiVVG3uBv8RkaGNqDrNty9jWyEmhpFC0prHV4IvEnQiFVN5zxmVRGzHIk+KId+JNd+dJcdy78yaEubV3RtqrS5scynXHdFZoPmiGfS/tFYMRsBaf1J4422rbIzGej5klwmVWFwCifdyYYj5YQZcncKzC9YWueqivLYTpRttG2C+VNuUd6G/tIrbfgt1G/iFyGPaWBY2iac1r5LO4iD2/l/t7ewN13UouFcbakGdzpWvYsxKDMbkCKyTVJoLLMrdWzpVNALLt+6MfYskx39kKTz2qWQZijXxHvChC5aWO4mrFtUgCl+4b53BOn3esdHHvwvAdWf/LAmOoGpUvXMKbL4jsS8EVMNFrYZLLelTPPQ7BW+MZ8nZjEKqroG8ktje4NPMrwcCqSlNJEVIQlZXWgaUZ0RYcc52DtP28g0N/K/jT6j/eLW69AJBBMr0CtJiWvwo0hp01dwB/g9lgytl2IlGbe1ZSIrW1pO1t2xgW0gqhjGQxeTQI5raSWhjB8xVxmFzMeF04dr6yXtY31oqE0ZyibQQg5QXm8S2lTa0exw3vjZPilEAoR2CIVFqBQ+D0JU2+9ao2uhK7x6V1wuS83HqcHNfp1Ihr9FGE188vth6D80Oxn38+KsuGuCUvwls7e0+P9va2HneO7aZqHL4XSC4gbUiprtHBFtdCNeX5tYZszJiJ0NQNh0gPr4aO0xrDM0l6MLnlfgx/31iYD6rid1w4zArXv4+Ad8yyqecKbWMqeZn8r+B4D74RsKQAW2yK7vnpqPp30N24tTqTTXFf0MhCVb5WqTg78ox5l4w0gW+gbwc21Gsi2gqq543+AZjyNOil7A0a9Txa//vH0zf/E2p/28ZFRfm8UL4PfNio2AQtop+JwWczgYZU/3pnPYFqkqL5ZHe6j0f7jokv63jgax7K1gOIpXAco2HBG9JhX7nwy98Q83oJg6/JccPk66KjicDc/bCUz8dPYZfjLF31IqZ5FHrJBLcrD6ITQELTFSI0fjwQpFGRbI8xsxsLrjszEkqyYyidZ50/nb58vB6xDc1tGpY0X7cPh1S9gI3PmDKsc9HuLRGACN6wlE+xtm1hY2nDHqgEHx4UnTledMpL9pSjw/1nbRg/L2Mg4xFoOKXO5Ux2mYNeqo2lKaN08BNsg3XE9HMAK+42ZV49424RlNo+jVr5x13wvE6Th6X5MfxOQzIVexRtItrfXXieB91t4seCUDfwik8ed9RLbubCXW4QFRcwAyAbNA67KguprjrxzRtMqwd0gV0UvEcjlksDSgZB0sFIvTGWekFRm8BNPwA3Nc1VOwnEenTeYbVIyGnk1FzoVEH7if68QT/7Seg0Li/jxl/SmqopvLH+hoyStEAMV6mO1G7RkyShtBQ9UspyYWQ0pzmRLcAM3xT995CdniVhMuiPNDu2rqpCRsfknZSbryfv7qvPufsK8+2+sly7rz7P7iHH7uvMsfsa8+u+gty6/mUhyK/4YL0Eu4iJPUnYbynIqtrEmcM7FD8OrRNEIa55PJyklSUe308pWPJVJTF96cylGJ+gbSt6++fw941molBWp2Umorr6LNNlVTuMFKYaULEn1Mk5hsaGxk7DBsu0p1NjVsEOTk15n3aeQAizBrUQ1JTB+OA0MtivFfAaQ4FpxAU3+ZIbMWLX0riaF6F8kx2xl1DnI6mhA0Yo9rd6KowSDhr85OJe1TFMtpBOZIn/6rPmRVUhLi60Ykjm653zjy+eXT5rF2F4qIXwUAvh/iA91EK4O84e9LSHWgibr4Xg5eeGINn+mcZOax6mISMuaZYXfK5LckuzSYBs4nWH0p9fI1xtsMBrr4Ti9o1a3Wdtkod6TlqW6dhGPIbwJer4gvnGI3CRkzc96q9exZVqDsEIFHt+Y2lU1JQpehldgh6zE2iwB5jqYuHT6lyABiSr4XoFm6lP8TNt5fCcm6LPtzfSJhjTKMUdqDKhyIQSP0DJLwzsICYJQV2/17wA03gckwqFYQEGzLjzAJB1rklUggRw2GvrJYlhuchkDrmwXncFMmoYu/bvdzZe2/GMl7JYbUg0vTtnOD57FGx9RuQL7kYsF1PJ1YjNjBBTm4/YUqpcLxv3f1MbD97swV0XmyrF0dN5qRQGaPnB5xMSzUMS77AKyjOPgzf6N34tuiu48ir/F1sDzhbBhjuX4UtmnRkqbXo4Phzv7ezvH+xQClgX+g0qNGvwHyKVE+yvQ/h/dqEN1+YvBXGYj+je60bajlg9rZWrb6J1bpayR+uDhRQ2B/xdaWR/b7x/ON5vQbupYJfQ0LPDfn/Uhup9hxrE1FWWPA+t6up+CGhLPIl1kydQHv66HCUKMARZJ7puvKyP0qatSWXx1OPRyOo44pDMHihr8lBcqE1dD8WFHooLPRQX+rqLCy2ca1nxf764OIO/79N5xH8Uw2HHoRQMm9SmmITAVIGB00lbTADSFAFeamt7d3t++GCq89V4oI7tbQEZt9ayPW/FZ7TBZDBrF70vXjxfDyIF02zoDF/QdQQ340YofxZFodlSmyIfhnYDuLzQjhediJcORh95YOGwLwT3ekBfudo/fDKM4FK4hd5YTl8LpThVJ9cZiRyzAKAyzFSk6QFOs0IvhYH0bs9CQ7mpMTsXlBOrs7oMcV5xbEvVWbZOQ1i91/JenZxv9c1jc+FGrIIyMVXtBtEETZ7NxgK23tPwTfZMirnebnreY492d6eFno/p6TjT5W4HdltpZcUXP+c47V0Pegrklz3pN8G5/qgHeL/0WSdoP+2wE9DWcVfbAVPvvWLw2ujDMYeNu4d7bY/YZm9zANe66/H+OG1VEqpIkfB+TX/eKrvRvMRbxXs0ZGymSTh3EcKw+E1cF9+FpCYPVXR4UP2vXk4itgBopTQvuVGTEZtAKTT/DzmQ/imMaS1nk2m0ITmtlbLlFxPSanm3JAGc8uSNRP2dYeWlQjr0tDtWQ+GXqKFW3LSqHJ6iidPwpsjghIYNOhpSRWoMhYb1oSyMHzHNvwt7QaOkaZ+drE9a7Ki3oJDWG8dc8GsR04ys31QMO85ClUSMJkQjgFCZxm4HhimxZIVUwkI7uOvkQuKvMoXgCnLU2iD/2axkZjUlHW9vg8j3Yj21A0+DsQsUgz+dnAyeNvBJvFnR2Y+Gc0yMSbnB2+TRLaX4QlpNO6QDTSdlWSvCP0YA62thAgdp4kcY7kKSnkMhGTZtTxTe+KQAkDB6pwZHN2EolP+5TwhGha01NphUcoy3tLm8FgqDcdNZicNVRjud6aJdgIibqXSGm8bKzyhdlVLHoNCgxUNRyszokLI0AgrkhdUw2QpPfvOyvVpVorGcyez3EZvxTEy1vhoxt5TOoYNCWrZM6wx5VtMUf2pKd7JrofKkRhJER2M7xBhJ7EVsHiOHYxkEPAW7udexT88wXNqOoCy4HbFkzKU0IUPwK9TCuWy3cvvcDVa2UbtCrcoZrizo3LAjU+3PjTSCqrK1cvYnVG8KvqRU+rRYengeyveM2CQcVvoJZZdsdsLWZR8BT569aCGAOIhbXW6uleUxWq2ggCckjwHTTirRn55h/UiiJm7ZUhQFMbm4nnD8msCENv8bxwRzzpzWxQ6fK22dzLz2qHJuWq0y47CzQi/TzXgtuFGYis5dvAXNpVvUU7j/eAKBgmm7EXk7Mt/xutpA0d+jxbt/tm8Pf/7nNz89ffP33ReLU/OfZ79nh//173/s/aW1FZE0NqDebL0Mgwc9LbBrZ/hsJrPxr+q98OvBokqNOD36VbFfI3J+Zf/EpJrqWuW/Ksb+ienaJX9J5YRRvMC/PAU1f9UKCPdX9av6ZSFUOmbJqyopO0wNYL3w2sGeeGWTB0rVZ0dRICWKTTpm5Fx+mG3LIDTJL/5aiuUYYVgzcUCNNqwSRpbCCYOAtIC+G0wNIC0I/H/Ba0GTpSPHScdbXXIi3LfoZqbNkptc5Jd/Js4g6akRU9LpuCY/kYJcGf1xoALVDwfj/fH+uF0SRXLFLzFSaUMM5vT47TE7C9zhLUzFHoWTu1wuxx6GsTbzXRTMULF2N/CTHQSu/2D8ceHKIsmXPyc+AvIqVCcJX1niP7yAShXAwUDjeSvcj4VeYtE0+BcZZ+O4hZ6HW19N1tmhNfUQ3s4u3LQHBJWj6YppcGhCCXEdpK9totWCXOpC+xMY6H6RM9kC+8+1OSGBS4N8ksilbweEbvPLgNgNPzb6GQngYcF70DZSBKrZxFX29fNwu2hkJoRPMPFxDBJtxAqgqN945jVJjzQvexsN9+vT3KIrJHrCA9SbQOG5J3huIy0nTAy1dvCa8qbmg2B/w3nSYxhbAjQYLvjKM6c6r0bMZdWIyer62Y7MymrEhMvGj78+zLusg/gNhSCcotB5d34KGdcFCtFlGioQyPq1x+LY4+4QMZjckiorshGrZAkI/frQ6YFOTANUlKbVCOJd+uymVA8VP++XBalEJnkRKHgU82Ax5K13pcY6ErGcbi6cyNwojA8fYSGR20fcacs3Uq6SEq7t5NYYDMJZVluny5jhgYNCD3FwbNNSO+VNtJrJed00GHGamVrdHQHM6pnz0yUVztoZJzNpxJIXhR15DdfUEL2DGJJa7VYGlghDhfjDoEMmWqIVymoT61YtxbQFRTIJxHsX2lo2NLRH5PHZG8KGTfukBmpIDTgcazyvsd8Qg8LBMWJErUZp/Tdcp42kYENZFyQH2yjMN6A4FFOhMamkCntDttXfa1HjwOzVxWvIUdIKqCbc9agAdLs5CZFTsDQZAaZBqF2VC6j6T/iAlq6vTs7vYXR6yKt5yKu5P0gPeTV3x9lDXs1DXs03nVfTTauJ0rdt//g0o0y/x+nw8F+sT2lLUX1IcHhIcHhIcHhIcPj8CQ5WGMmLzRqMw/2aJiN5f1u9rM/X8iv0EEjZamzVclO5emEor9FfDIPmFAzRzUirStjxUNRNcBWYtJlAuHhCFE5u4T+VpcZfH1fwD10UAsJ08BLr/9VcQQdiI8KYLZS2vM+fE6lx5ThDGp4+7kBwc8fUz0BSCWNpwpbmXMk/GmU/mHm6z2+JA0nHCfd7oYzMFkg4cLFf15GsrLgKUlob0ldbRNeJ1EgDQ5qOowtRVFBsmxvD1Tw04XFU5Dbp5MMVBumAx6AdoB/BaNZzn5Ic/4CUlBTUL1YaJqWPqB40XL1FSpEFnwMLvoWcLsDO2mkCsIZ0dIe73z368JvUDL9xtfAb1gm/IYXwG9YGv3pVMPGQxhYdxOXOkkd3bpG9lrnFXr7Dki7jqpF2Tbod2ZzbHe0gsDG2Bpb5bkLLFFTSiqsFBhz6qo4rSLubOaGYdXxlQ6nj0LMXe2zz2BULFMRKoqMGkhILPeVFUnQ+gNsYlO5W6mp+l2SDT4sBM4avKFwCkMTNHBxpqZ3sDXSPJH0Cl1cZ7UTmwHkinbxu5Tv29E76c4fZmI25w3aK+M/axjvFDgtNfdpRFOKjyGpoeLAhVBxPoeeLwHBd2sGAlWb23gnZra3ZnUq1G9b2JUpU0okjKRQ3yl8toKMEy3hRCMgOnxtexlxHK0tZ8IH+vl3gq1sTQtdFfpzF09YpOt0b8l55J2HYikN1l+7of7a/yUXoc5ruOvUx6ZvtD/b2n+3sPd05eHKx9+Jo7+nRk8Pxi6dP/qvTAGNhBM/vlqm9btkXMAY7fdkX2geH7YAuYMabJjiYpBOG4tEFz0eYfIAUCO5LCteoUnJlJ1xhdPW0aWrpjuKQSbEBxtnU6KUFk0DI2SAgwhFdiimr+FwkbUs1to5v78ZSmyup5pcYdtTrVP1ZE81oLhbnClaFKNm6TGShS7HLC2wZ0aRuNf56ErXvk0c3itqmuY3ApuOhXuiMZ7KQzsvMSl5r7P1rdA2N6yspsqRdFPRHCZsNdgt4wXYbm1CUuhUCOp6XXK28bpSBx97fOF+dnIe+ShcpCDQ0dqYD0wpe7MoR3lgh4D+IKOgQ5acIhaI0+YtArNpKK6+tB/GOWSmKTQiL40lcyTF02TXCRTuMx1Bj2Rd2lKT1TAWrocwQ9LSPRo0RhWGOGiIIAWojlhUSenCFV7nKY8xSGhcKZTjg2l5V0MC1KNjpWZD2TjfQy2oyQpWHgxaiCGlUWwCDAE/PmDPyWvKiWI2Y0qzkzkHeiYjcWzqYjBuRj9h0FWNp0qmO+Hg6zsb55D63/7s0wRj2qRwXMU3t9MziHmuVdH1OL9j9sJzzuwXl0HsD6TpEPFSdIcaIZFopCiCaRfsYRTkYMecmx/ARa7GXd/O+xZ7kMoY4ei0QI0wzbZKuwD9qwy5OzmJnHmCaEUyELRPS/00IkkpCqYfzv7+l6MpHNpTMD+ryyVkCyxgmwYotMSa2OxNVoS1WPXyE7WuHpisbmg8CV6AYGMYzVwdfKgbYCVOyrTjeFhYsnkVtL4VCdQC3ocYX/Ezaf3D59hOdAiuhcq0ZMjbbmSJdBzGk89YEHLpJwSpoxCZCB8tt/FarrLle4Emnr4cGa1DblOJohvSnF7dxB/3oIZWU3jzB4XfDEtqdTfA2xHPP5UuunMxCzDslS4mP2JyI+FlzUfE3qFld+NeupV+u/EMkVkfFMmHgftbkKwVeZeIcM14UgVeF9vkZd2KuzQqZFeWpWSeLggkFLe3gtTUZJx5hM+lVVxqWV5XRlZHciWJ1nzsTcvJNqUNow8dmd7gxUXRgrmNgMOVUzmtd22KF1AzfRFUHGv3bqLSDx4B7Nj5iPJTDw9IxUERPezoZM/b3BrNURjGtEIKnyt/pY3YA0v1kTA8odbWtxikvGZq8wrzGKDG87k28/IESNGMEazJiufAiCzJJQ3nppl0fyBnZ7eT4udO6/gr5XFD8vMmII2cLNXKG89M3a7xoh33jom6B7JNKzSA0OH6ncdRDJNtDJNtDJNtDJNtDJNs3Hcn2iYFk2/1IshBH1lAWXj87blp2enZ96B+cnl0/axSPjqz9YgFoQ9Fvfy557Iyyxj5FsLdtYnfIQ1oLhIbCHWuX+FC88qF45UPxSvZQvPJbK15JpUXgvcSCFh7dEuwUCpN07TEu/U2bgX5CXhci4JbcskwXBTR8viWgaSZVTkWeAnVCXjaSZazEFeb2b4aYgbubC0S1EKUwvNhguY1XYY6UPWlSAAP4j+QMxD30ALePu7WWZJ60hADLjmU8M9paZgS4q6h6zYQGhNOXa2iw5Pqq3wt+OHu6tzdrKzSbOE7bfdYcqtvVSqEhFSHuL5msEngCi9gxdNVCHaX5l/xKWCYdq7S1cop+okg6cWggoST1EWlWiR5BDbWZCDZ74/epEkYKlYFvytpaWLQL+rGMyP0CqJ9XY75HR3ocN3SGlzkm7jfBDHDlCsSOdjOp5tDpmHqE9XY0f/JcPBXTmdjj4ll2+MPzg3wqfpjt7T8/5PvPnjyfTl8cHD6f3Vai4PM3kAgU3sTS0vkfCKdNb1HxQwiwJdoHaQQ+j1jdodBLC/eppY7oaa5TYSxoKBFYhWmILygG/vdYOB1vfKrlp5StChHUkSKeNhBvaeOTAoudEXh+G3NpnZHT2q88VJzCvTU1uD2ixFlo6+ww+aKVPlilabEMi7LQUjqhAZTFDSnUesZeFdw6mZEPKUEzLIFyf4OYRn27tk6Y1q0I/Rd/FdzZ/hDSeuzkYsbrwkFNoCq6QSO+HPRoBo4cx5QzpjQLY8TuHwNlCNM17KRJp0lUgNuIMYZ6zMD4HTr9x4Sr3+t0wYfBtUmJ5agfD8jZFpP0Eh24ZKIwhJWs4ZQwSJMUDKeuDV2bGEcd6oiDxooDk9bGD9WnTH9vbcfmAs23/yMEiLY3JPpUWjpPf1caHgbVDvQV4/7UYPC2cNjevKPzXDdT8kh+/dJi44NxWtkAXS8t9a95coP2h2/d7ogLvh2ACg0Bu+3Ko+2REo/bLb621FNEDrev0iNEvq0Hj9BX4hHC/SDDUVpIqGc9+mJuIQTpwS304Bb6PCA9uIXujrMHt9CDW+ibcgthPbxvzS1EULNNu4XuLt034xsaWOeDb+jBN/TgG2IPvqFvzTdUG+RYZBj48P41/LneKvDh/etwj6dOlMzWFZTUxIQ3P5EDcCpuYC8/vH9N1fLozRjuvhBsagTH1Am9VEwqp5nNFsIzF7wsjSA/i77XLLD5u1gAhm5zn+/QvKTLOaHbFKNYrX9ruVyOySg1zvRW2ywLOTMZB0MB4LPkKwySpiBerxFgaT/AKwaVF6smT5a3l8YozwZMvtAQwYoRRdc3xaRBO53r2NaEbvFkCOhpg+0ltPA6M3xebq5z07aXtollrTYF4zNHpTkm308SRDtdbXWMnZPvJ6E5CfViQYWbgO7wjA2mmZ/OUFR6+geTkCz9flJaDgRW11Y0u7VKbC9YviGuSypoEwgSfjJiy4WA8H7XasdiRKaVdaYGg6OnHowcD8aftuEpVWMGuo21t//o8PDJLppX/+33v7TMrd873S5LO9wc6HMKK2x2A2uk/kBAIjbmI8XV9lXpt9pRRLpUA8VBR2ktmDyeTiiKGjZzhOk13KbbwzNIeCv0nC54/lNpKZ34t9q6JpQ/lIb1jG1tc52YvxU/i8Ny8HcuuY2AjlqMd9Dz+0kb60db83NHz7c22cnPvednNPxgE8wGBrcpBekMGvq05k54ECFoa3zLbeN+6a/JjaM35eHhk3566OGT1vyQ5rWpM+j5LExA9BrtFgAv/oIFBgbXEEneo69DVz12/m/AzsVHKASctHFIZ4FUFRSmsaeW0v5bOIyJYRyrNiWww6cuVHTiMN+0dvGtUTIZLhZDNeKIsZtSWbkGHgAd35zQ1x0HXMvDzKbCLYVoJDokUy016gkdmYUK0qb29hxGX0/uwEi2OiwV02AnR4OiF+Fdw5J6uvKGL7BppEHCR1IIWhqxvT3T8ILU7Z6rbLiQD7yKIgj6A4trHuUyKWdt99mPSSEMfo12IAFW4PRO4p9IYekohLscNtBxC67gM5mH9NWgvceEWxKKcMzAN0lYKu8TVvUPNIF8Q9aPb8Dw8Y+2eTyYO241d3x1lo6v1shhhbnk83D7STg7a57egb/jGIHLN3GZ/j5P1YVC9YooWQi4C3+9o9JCC72kNqRLMY1xIxA2k9SbxPIR3HhtoY6gBv3i7iwZ+0l8qZNMs3W3RJ4tQmDAl+qSlFAIoq4H1DmfcSO/5N31g6INvW7HDjXENeCj/0MWBd99Ot5jjxCN/8JOzj4QStm7c7Z/cLmPjSpDjbTH7LiqCvGLmP5Nut1ne0/H++P9p5GdPPrbzxdvXo/wm59EdqUfM4pm2t0/GO+xN3oqC7G7//TV/uELwtPus71uidiHotODUD8UnX4oOv3nIP5fW3R6s6D+R5/rrhENngt+t+MnOWJTAS14SGv4K/7VGvdf4fOTYHjIdFlqBd/FkMdwTQA1sqCqH1Qg+rs18YsAWadtwtDib+yFQOtrjewhGztZij+aaD0cmBcymjUr7hZHdBPtvFzKueE4nzO1aI+Oa2kNq6e/iSw2wIY/Lm9dyb9GeRUxCzsW+kwBOikqtA0B9LJvAdCoSGsneeU/6hSrhIoyeS6poo/X0iFOlWLqYZ5Y2yvdQzYcEb5uB28AqwEtCblubWSPOvqb6Ikofe/G/YNBB8muP/AgjXZHp3OUFbrOm4N04v8MVgiIFueUMDaAiTf0K2rGWetT67dI5CE1g+f5JbxwGYYMRdi0SY9aa83wwbgy2pNmczGP/IB+2fl4Mw2liid94unlJ63nhcAV0w5+z449MjELqcjTQxMDd4Tj4wgYLPWW3Rh8+ca9TuYIWSVNQtzN08SMpPj+vWe6A4F15rorDSezUXLPZXIMb56MPhgnH9x1LmLzspBudXkH5nrzV3edlSjtrhvXo/K7zoPRdneao/XqGn6Q6+wKqJQYwsvw98Dhwt8g/aabVEG/+aNtF9q4S5QPR2zGC+tRyVW20CbMtxOZwRqxG8Fig9JjHZcniZEGoAyjKUHV8CeD27FmqpLP+7Ll1tn8V+lRuuesnS/vNumnT1fwqSisZ5kX716+8xrOkjnNSl55PmvFv/Vgaakb7GaVg90sek89rhiCMA6U6+VdQ7c/418Dg5x6fSGhVjLC+s9DzuE4IVDosz5EniQxXp2cpyk0MubEiMyOV2UxpvcwrZobCkTWaqf5smNkRdBvpvT1W9OyhIYhploXgqs7onfWYAS8b8229+fVdjytZdGfsr+jUXBv7b94ub/3w9bdwHl3zmCGduMS2vWreuovwZiHQnv/t/TZwMDN71HBaWsrzaAs3fmbOVnz0a3crAX0zfvcRXel8+Gjfq8DlGCg0tSUeXCqeoBvfupMZzpnH05f9ieCePmKZ59vUc2I/cl03mOzf3KyYCrqT4Ys6nZWeLeJiOeWvOrPBK4JrBD5uaZLhhye8xbh86n4jMOuQeptkvbPz4vjEodpOi30+iwMjBsqdEfGEu8QQ4wg7eJwHy4gPt5V1odS173C/Wy9Dlha+3vRNrCkj9JVr1kXpFt19LUkpsaI32tpRE7T3rhJZ69fHZ+/Yh/OXh5fvGIv3518ePPq7cXxxem7t9/93wAAAP//1gaVsA=="
+	return "eJzsff1z3Day4O/5K3BK1Y2zb0R9WP6Irrb2tLaTqNZ2tJb88nZfXnkwJDiDiAQYANR4cnX/+1U3GiA4HH1Y0Xidd1NbtbE4ZKPRaHQ3+gtfs59O3r09ffv9/2AvNVPaMVFIx9xcWlbKSrBCGpG7ajlm0rEFt2wmlDDciYJNl8zNBXv14pw1Rv8icjf+6ms25VYUTCt8fiWMlVqxg2w/28+++pqdVYJbwa6klY7NnWvs8d7eTLp5O81yXe+Jilsn8z2RW+Y0s+1sJqxj+ZyrmcBHALaUoips9tVXu+xSLI+ZyO1XjDnpKnEM437FWCFsbmTjpFb4iH1H3zD6+vgrxnaZ4rU4ZqP/7WQtrON1M/qKMcYqcSWqY5ZrI/BvI35tpRHFMXOm9Y/cshHHrODO/9kbb/SSO7EHMNliLhSSSVwJ5Zg2ciYVkC/7Cr9j7AJoLS2+VMTvxEdneA5kLo2uOwhj5paNzHlVLZkRjRFWKCfVDAciiN1waxfM6tbkIo5/Wib4+d/YnFumdMC2YpE8Y88aV7xqBZM2QabRTVvBxAgsDVZKYx1+n4wCaBmRC3nVYdXIRlRSdXi9I5r79WKlNoxXlYdgM79O4iOvG1j00eH+wdPd/Se7h48v9p8f7z85fnyUPX/y+J+jZJkrPhWVXbvAfjX1FLgYX/D//OCfX4rlQptizUK/aK3TNXDhnqdJw6WxcQ4vuGJTwVrYEk4zXhSsFo4zqUptag5AgKdpTux8rtuqwG2Ya+W4VEwJC0vn0UH2BbgnVcVwPMu4Ecw6DYTiNmAaEXgVCDQpdH4pzIRxVbDJ5XM7IXKsUJK+401TyRwRPGal1rtTbugnoa6OYcMXbQ4/J/SthbV8Jm4gsBMf3RoqfqcNq/SM6ICMQrBo8YkafpPAm/TzmOnGyVr+FtkO2ORKigVsCakYR7jwQJhIFBjOOtPmrgWyVXpm2UK6uW4d46rj+h4OY6bdXBiSHiz3K5trlXMnVML4TgOv1oyzeVtztWsEL/i0Esy2dc3Nkulkw0WcTktWt5WTTRXnbpn4KK2DLSeW3YD1VCpRMKmcZlrFt1d3xA+iqjT7SZuqSJbI8dlNGyBldDlT2ogPfKqvxDE72D88Gq7ca2kdzIe+s5HTHZ8xwfN5mGUPtdF/7nT8szNmO0JdHe78V7pV+Uwozykk1U/ig5nRbXPMDtfw0cVc+C/jKtEuItnKGZ/CIsOfVpduAZsH5KcD/VbSUnC1BJpzx3JdVSJ3dswK4fw/tGF6aoW5EjawqwY2m2tYKW2Y45fCslpw2xpRw74msPG11c1pmVR51RaC/VVwEAM4V8tqvmS8spqZVoFCpXGNzVCh4USzP9FUCaSdg4ycik4cI2cD/lxWNvAefgtwFewTEEJzgbgl8wv7fTEXJhXec940AjgQJjsX6VTRQAACKOLGUmuntIM1D5M9Zqd+uBwMAV36ScOWga1qxx1+GbACI0NkKjixkd+/J2dv0CSRds2EaMV50+zBVGQuMtbxRip8Cy3C+qDURTuDyRIUO4exQb0yNze6nc3Zr61ogWB2aZ2oLavkpWB/4+UlH7N3opAWOaAxOhfWSjUjyOF12+Zzxi17rWfWcTuHl0/O3rBzYCdDJPMbEZkc/+6slW53iGYuamF49UEGqUP7WXx0QhWdLBrs6mv39epeehXGYLKALVJKYTz7SEuEfCRLlEAopuw3ka+DTQOazNRoHQQDjudGW1D+1nED+2naOjZBcJksJrgeoP+IGInQeM6Pyif7+2WPEKvTj+Lsd039vZK/tuI+8yYmP0YW9YyN9FqgXp8Khmwsi2unV/SmB/+/iQmS1QLgexJhsIKWcdTtJA69CprJK7BpNehKv3L+bdJQc1E1ZVvBJoJNTTOMgN1Cs+9oQzOprOMqJzNmRR5ZGBiFEjAJqVPWqVPRcMPJBKHpW6aEKEA2KbaYy3w+HCru7FzXMBiY18m8T0swfIPkwal6kRQe6dIJxSpROibqxi2HS1lq3VtF4MRNrOLFsrlh+egZDsCs40vLeLWA/0Tagilo54E1ca7BGkd4qM2D0GUgt4PMjlTt3vUsTkNMRfcKqjBZ9hY+whwwQG/xa57P4UgwJHEKJ9CZDpsbIPW/0zG2T+wVnJ7CGXfX5IeJGZNXcsWOeVHJOxgyJ/QlMFwhSjT4QLXOBZNKOskd6OkSdqdwC20uWa6VEmiQgyoNuIHCBmk746YAZregl7Sy4+R9r7Sm0p/0pVa8YmWlF8yIHGy6yFUg0y5enBFUvys6NAe4wQN4PcEMpYgVKpor8M75P96yhueXwj2y32QoOb2l3RjtdK6rwVD+RAtqpTcowdQGj+sCDkXBEghUcoYry3GWGTvXtYiqvLXexnHC1GyHjgBOm52AqWZGlML0UFErE7TezKCfyQb1nDQV0QZDGzSAnQcUGKClZozblSFS/JH0GXvRGwB2Tmtb0LMEtTP+pAL0fmkV4udtQTCJ4kEmY2ugdQRW2g1gglT3C7aLVgcxROQTgrcXBopuChTWXk/ASdiKmisnc8AQDoZAY66Y+OiNhbGX4ARU2qhYnAb/Ucsr+ZsIXhM4UrNcGLT2rXQtp/U4LdlStyaOUfKKXAAMPiG95sRMm+UYXg0S0ToJ3gZlW7R+efSNgNQshHXAH0BTIH8pqyoaXbxpjG6M5E5Uy0+w6nhRGGFtX349nEGH7I5LFZiLBiThG+VMPZWzVre2Wnp2xm8IJGMLIIvVtQCfDpjAFg/Np2djxlmha1gAcNWwVsmPzILXwWWM/aOjLOkI6zrRzHAdDV8EnALjTzJ6MPH8GpkMbEyh4ARAUGGDtd5p4Z0tk0w2ExBtk8yjNYFjXCNUQTYGshcYsBEknieyUW9VpksnVtZkoFMqHW19f7Tof9Zbh78CPH+siJ49Wg84N4M8wG0z0C8Hz496iPlJ3YLZfTiF9q+Hn/XGnAmd5dItP2zIMn0h3RLpPpj9G62cEbwaoqPB/ymU2xRObxMrOQ42wO+tNm7OTmphZM7XINkqZ5YfpNUfcl1sAs0Xfgh2ev4jgyEGGL44uRatTa0mobR2QV9wxYshpSqdpzb9dejMhP7QaKncunFfazWTDhwqIKsr7vCPAQaj/8N2Kq12jtnus8fZ04Oj54/3x2yn4m7nmB09yZ7sP/n24Dn7v315AEgO6fVwYvq9FWY3yOLkJ2/uBfKMGRnfSCD4bWa4aitupAtWAAuOQyO83ysRni+CzIxHG8/h0vjzUS6UE4Ysr7LS2jDV1lNhwE/mz8LBrglSjhF6FWvmSwtRgehay8O27oxJxt5ql4QP4KgBQp+3TtcowmdCh9lmo9W1m2rrtNot8sHaGDGTWm1yp73DEW7aaLt/f3EdXhvaaoTT2p3291ZMRZ9QsrkFB9msG2V0ehYVdJCIqCxSzvJeAPCPaNP5tE/Pro5AGZ+eXT0NMEQI4wS0ap7fgtd9aPPm5MV1WKeDe5PW3oJAoup7g5z5r++l2A/7eGjj7ouENu6mKbZWmEzUXFb9AR5MeoHwYjhAoPgaBMq2qj5sUIQCEiPLYBicN4osfsVlBX6jAflPqqkwjr0CV4SQaogvWu3ZxjytQ29jSZ51HDg6RPCUuNdU3IGNuYau+PomdVNqCfnBhkjMuZ1vaPgRUQomCxHqOVj5uTZGwLm059YHCnJECHWK0mqZBgkZ+EhSr997K8hlOYGP0BUNJwf8Ayg6iaGkXKvSe8R51RsTbI2cq+7EzELod0XK0Qh9Kg32+H0o9OOK0G1XWSsKQMRhiNWQeR4Er/M5CCYADuhVeibVEJFkS3Lckj0/mm6LvhstPLjei+YzPphnjyII4bzSLcaupCoNj2HgLsDlT8PeO0yIgTzPbgholeyNcEbm4NoEX1jiyOaQCHPoY2vAIaVw+VxYtLIS6Ew6SzHEDkng6MB3dhjDlBAi9A7SPgoE17SKgpNG1NpFdyrTrbOyEAk5VjHzOHFG0bMwIQJMZ3P8lCzEfpQef0kAuXk3eFCEMocEkg5VItin+EvyHA4Ym5PMo4uOQH4s4BttZlzJ3/CUAjGuEPKmXbZkhSxLYVKfCfzgJAZ6Gfc20a4TiivHhLqSRqu6b0R1vHXy03kcXBZj9r3Ws0p4/mc/vvuenRbov/Uu08GGz0are+vp06fPnj17/vz5t99+2yen15CygvP9b51b5KGpepKMw2AcoIr3xeC5AnZBsokGwqG1u4Jbt3uwYtJSJGFz7HBKI7DTl0F6Ia7E2QNE5e7B4eOjJ0+fPf92n0/zQpT76zHeoMqOOKexviHWAaXwcBiyejCM3gQ5sGxuQCghozvMalHItu5h2hh9JQthNoRlz+mDey0MmIUgb5qAxRd2zPhvrRFjNsubMYFksDMLOZOOVzoXXA0mxxe2Ny1/et3QpOiQeM/tlqpjL+iF6ank3sMbglvxxX4AgyILg/y4JGWnEbksZTgjRiy8e55iUOSl12UKJIrWi7mwpK58QCExIFFf+fTVCNqSJlRL0FHg8v4EBSWLDdhSZAR3k5dFfw/Lms82KlPSvYGDRdeoRwiSgKatrByo8zWoOT7bEGYdZxFefNZHIMkAvXn0JBP0hlzQleFPcVBKq+yNu8HV6ObcOX/CsMSyGxr5nYfOaq74DKw3VN+RDwaSpIBYkEnESBJFSwXJy5XHN4iS5NWbw63IomnUDr2p3uWz18/EXAMzibDeFlv10odiq19i7C8lwt0CgASR0gkeLAAYwWIg8P/vAGC6KE73svT/VVHAdBtsQ4HbUOA2FLgNBW5DgdtQ4PWhwESJ/dHigT3UNx0U/ARlv5HI4LWT3YYHt+HBbXhwGx78w4UHff13DA76CvCbHAdvhOO76eoE1yJVmGd3PrjfVnSwpnL895BqlFbVo7/FH8qB7bSpoUI+YxOR24xemoBvl0c0CCbNBZmybq3zpUxodHUl1h3//wQn7V9bYZbg5qEarshGUhUSKjh2d+lEDYWLhBDQ01ZyNnfVusBYMhv8nvoOAGoVKE6pnJgZXCLLePELoBpUZj4XNQ9fR4jENzSFgbGIjQhSzjFGmx7vxAc3uJ16XmRIZ48p7h4g7iOuluxSqs5j8d6XGNQofug99Fz7ikogXiV8GBbITMFojFRj4Y3tSjHDtOAVCB2LqgwSyIIzBqFnoztz8YbM41eABh5B6fkUJgYCxiPYw2EjIu967bkGA6qkvgWNWMO+drKhGjvlsZg/H3gsPriZx2h910VJQjnD+kBJpYMRiBhBXkCPVyJLnkDN7UqREVedTAGGgiULvlRdes/fHB4mvNuVib3uyvhRsITSZkALHIZwWA3RJ3gKgCKMEFrDgbpJELwAiocKWyhrMy4kWlD6RFcS5W13NhXwRjTBCSYnmxsEFE9Ncoymr62rmgq3EAJGorQ+kJ6csvoIrB+MSpKgDtFA7gooeXYSVuJ2cvvDEoGswTuqWp9ZXiFEX6+C5+q00BzF+XpCJ68R2K5Uu0f1lFs6ktei1mbJQMhhPQyBKxLCE1ht2FVbQfkQRvilsCsvW8iREgV+9AkSiodmEw8tIUYXUNGH0FnOG9earidJPzAAJSepswMEcW8DksuairROMSSJq9dZF3Ou2MS/EKqOJtkg7QP3+gSFwy4vismYTYjld5HlBT6Csvjd3AgIRkx8qU7oyxIhxgLswHE0MwkLDkkn61JEwNbbbbi1IG53fTVWbzEC6ptYjldAnFiStUp82iSWzeVsTuVn62UgvImbQpeDVYkwcXWw2m1lcTy7TcYhDGGFslQG1jmqeEQz4tVBDtaRh2Qz9hM3kOMEeSSsbIHPOtNHl9DSYcwWgjUVR7cA5RswHkFW1GyD57loHJ92KQigETrTacwa32UJahoxKpXzdr3vDFca43edaIiL7DnrljWODZBW15GY3AMZZLGt744EMgkbBhFEMJ858mwoNUfpPF1CoZ4ZtgwiJkGFCZuvkODpyMn30jV5ipV/yaNuWQnXCDNK1DU9mWKvmFVRcapYDVktXS0iOlCBiRa666cEjWd8w46hley3dPgzD2RmodA+JJ7lvMoxJEnenYovo65COpGmo0ZQoGCC0ukSVXqqYzEPn4ZuKtDEiUQQOGdXSv4DJrVWsivEZQmI0cgy3a0Y/BlSwJxml0I0rG18eSp+lHaj6lMVLGGc6AodQWT6g3fOq3G6sl18cM1pG1zcVrhbuPxekiz1h9AwyVRgbXOtYCvDS5xN6J0JewSS3QrH9shksMJ9A/wcPOO+swTYasy20w59BpBqXbSVsCjqetsulZPeMoBwfWuA16plaCIlVTdoeuD3LNL95IeBRSVs8eWhiLGOO9snedtr23AHV2aIqa58KVXTug/hR8WVtiLXXXW5bl36ArdvZFXJte80RuQSZPExO1i7mC9p6LCgZE6rdNiUUUtSOKivkXT+bwE2oxHsUukFneC90u641K3f9WFLw88IBbo3IPQkLSnQWKjiDm6264R3h2qPgeD1VZGNQIEL4nNQeFdp6AmkOrT1C42FiqyH6gZdgj+AF/BRI8ycNxaOOr7tTinVTJjGSOW+gfWEumOvM5yGBUDV6jRBBJi1VtZBEz0AQl4J6ZbZKrN3CZ/r/nXy1xcvP9uR9/QlSORgrHYrlt2p8ww4Lvq4PdiioMEN8HtbqScZqfOKXXO8XZAJtprh10GKPNspt9DcjY6Cia/vBktxxRrHp5MO5gQEm5iM2YRX3NSTL9PAQyR7K+vldn9tH4TvevqOtAPKt5sb7qDFli5k/80E2qr+0yZ20hpOvF7aX/sZIsFU28TU3/EF+oVCMz4gA5giJnLTezKRbpAlfZJEIxb6kklViI/gL4DeEzr/QGwBjFlIC/Kq8PoeAwwgw6zgJp+LomNYaKIkYxMnA4pcXAVbdvLBG4mTISXPRcMOvmX7z48Pnx4f7OPBnb149d3x/v/8+uDw6H+di7yFVAP/F/RKE9z5M4Xxzw4yevVgn/4RkVqAj9i2ObhzIPCHZkjTiCJ84P9rTf7nA2ghu58dsMK6Px9mB9lhdmgb9+eDw8f9MKluXa5rsUnxRUNcJ8F6LVU7fwEcYvA0SF1UybHX07E9yLGUh9GHqa/Gv0jSiUhI7T1LLqvWiLUyKUK8k2y6u0yKcO8umzzOvbUz0l5+sMmmvG6blpXmbt36vJP2kiEEsEoaIzUwZ2+l2CORzTJmiXGZ1RWiCC3swizAWY8nER9YHdnuqIfzZ+CKz67B/QO4XfoTWMt/105i9Bb0GjS4KZi5fULj6FoDizz0sWRsH9byYH9/VbaAX4pL5cvuKbIJrW9An6BLBF0h4IX0s0dWZNxaOVM2Qch2qw58ByAWUNQEQR8B3KO6aXiqUewImlRS56Vs1COiFVfCdNbjHQ4HPcKd0+crXrq4dgF8j3wZ+wnm18VVWLS/XfcFsX0tOBxCFej25LAeT9xAQzij4gFsFI4ZDI63Tq/63mB9an4JvWHBTeiHkrSpc62stA6AE9lCYG5lI42erdAQTgV9At7D/Pcnl1sPAOSQTI8ABNMLLTgKdI6da84AcILZYMnZKNGo3Tmry+TuTwmcE533IOkQ6nuEBp94wLlvpFbgsVqShClEydvKsfOlBV0fgaaC5hTH0w11XsM6voW0qdfjpJO9cVBvLyGjHENEgiutMCBw+pIG33nVGt2IvZPaOmEKXu98k2zX6dSIKx+jCK+fX+x8A8vIFfvhh+O67phb8iq8tbv/5Hh/f+ebbPRZehy+Exhe8UEvMqpbsLAS8lBPeX6lsRozViJ0fcPBzQnMy7O0xzD4LdKw3Hfh7xuicifYenA1hMPAWTM4j2B0zLIpHNrJTU84U5QJuqxj4D3ERgC2F4txeoAUFaBEdxu3Vueya+6LFlnoyhcCV+Fvroo9ctL0w2m4oGCJaCuon7ePfOCQp8EuZW+8Uw/I+p/fnb75r9D723YhKqrnxfZ9soqR8WBFDCsxeFkK39xeVoP5ENBOxMQg5ifEi/I7Fr5cJwNf89C2HhYFxufAQNQheEV8FQKqpO8w2n32wEsEfk2NGxAJEOzjg2MP01IeDKURskgcJdmLKGhJxPIKukgKbpewzE4gC03xj+TjNUkajZr1pjOTxYYmcmYktmTHHQ91vY++P335zfWE7Xhu07goXt+wwFINEjYeDI9TgN1ltISMDUAiRMNSOZWiVW8Oqze66NEDUNG541WHaZLRmjDT0cHTPo4PKxjIeYQWTq0LyDFZEQ56EWpiH54quA9xgBF6R0yX9R2Gb7ibb2j0M+7mwagd8qiVv92FztdZ8jg1gAErjTVY7FH0iWg4u/CiCLbbBGBhqtsEEJl800fFcTMT7sMGSXGBIzAYAU0Vu6wrqS5tdouV9GAIILmACkClSozh4p4x6zBZoUi7MZF6QVmbKE3fozQ13VE7ScR6dL4iaj0jp5lTM6FTA+17oW+zz74XOlgfYCzl3Jhl2jWFd97fUFGSNojhQWP2PTqo1ZIilJ6hR0ZZIYyM7jQn8jlmnnVN/wGz07NggUMU29Np17Zw14ooPsW4+XLq7r74mrsvsN4uoPSF1NoFrr4FlX9dnd2QTtsauy+hxu5LrK/7AmrrhoeFoL/ig+s12EUs7CE1BuwEPkf0qkZb1ysIyh+HV4yoxBWPm9PpNDBxV72yMaPgIYqYNigF1lYuhXHBu5Ku4g/h7xvMkJPYVqfnJqK++hDfbFpMXI49oMJGhYoI+DZe7LTeYZne6dS5VeDDrrFB54ntX9yEZiFG/dbmByeXOOFcka4xFZggzrkp4PasMbuSxrWQiOz7OtkxewktH0zwHKNYg+jA39qpMEqAIQ8nzHDuvwtfQihTwgVcrVlhgQfZ1T82IS+Owh3peIN9/vH50w9Pj7a9ELa9ELa9ELa9ELa9EP4b9UIA/bkhTEY/EOwgM3s3QUIYkILlXQK6pWS3uWCTgBkUGtc17F8jXGuU7V3eGFoojm606h5mPmTS4bgybct0YiMdQ/oS3fji643HcPgISSTRfgUTV6oZJiNQ7vmNrVG9pUzZyz4kCJSdQP9bFESTVSo0t1BhfZ8LWDYmm/X9CjbTn+IHWsr1Y26KP9/eyJvg5CK29FyZcGTCie/xzh80ooKQxKSuX+G2JnCNR5jUKAxmEyrueB0rpbpCJXCRQbEBZEeoAsK4IpeFsGTjIhtFoE4Db60svLZZyWtZLftUezDV9OM58/DZo+DrM6KYcwfXDU0lV2NWGiGmthizhVSFXthvBsLIvznAu6021YpjYPNSKwxMbggxH0oRY6GId60cfcNz9uM5e6N/4Vf9MjFts0sw+T/bHPxoEW08c0Fyt3VmXWvTo+wo2989ODjcpRKwVeyHe23T9A+Zygn1ryP4f6xiG47NnwvjMB7xPfiwtB2zdtoq197E69wsVhqp6Niv4HMhT8PdyiMH+9nBUXZwSxjnYS/0XBG/cCPii14PYrpVliIPve7qEAHCa4knsW/yBG/Bu6q77B9q1JnYuiTbwZBNLm1NOounEY9OV0eI63T2aNtcaNtcaNtcaNtc6I/dXGjuXM+L/8PFxdkn3zwCH8V02Cy0gmGT1lTU2BZzCJ3uXYsJr7SmCvjStbZ39+eHD6a6WGZpQ9qbtkeSkBEqJ9NP+8Tt5Wf00WQ46ip5nz9/dj2KlExzByTvwwkXdBzxi3Ejlj+IqtJsoU1VrMd2A7S80JDNZG+i6CNAFjf7XPBCmDXG1cHR4/UEhq4turgDzvch7ahHUj9UIuIu4hUxeF7znWGmIi0PcJpVeiEMpM6jCA3tpjJ2LqgmVudtHfK8ImxL3Vl2TkNaPRwIXr0438lGq8SZCTdmDXQrYU3r1pIJL3k2G0vYekfgSc9K22PGwWqC7LHHe3vTSs8yeprlut5bwd02Wlnx2fe5H/auGz1F8vPu9JvwvH6rB3w/914nbO+32QlpqPts7RpX722o99Dsk8/DXO/cPdrvR8Q2e5pDvGiIIVHwtBYQCV2kSHm/1rO76W7vXuK95j0gokJ3q7srYZx8nxAPYtiMfgxFTYBVDHhQ/68Q+qcvmb/tXvRKmhfcqMmYTbAVGvxDrin/FMb0phNKqTYxo1Cc1ivZgsmEslq+2pIAd3nyBoEF87eEQjbbVNLhuV86KMGSqrNQG256XQ5P0cB2cClcyGqdENhgo3muSJ2hXCVtYQBiWn8X1oKgpGWf/WmEyY4HEwplvRHmnF+JWGYEzdigOhr4M3RJ9NmE3gkgVK79bQeGKbFg0HsF7NJaX8VtyGCyeQVlbW2zinJCnntVJTOrqeh4NIL6e1TrqR94GpxdaBj87uJkjLSB/4S9WdLeD4xLhTGpNHibPLpeImDDglBW00/pAMwhINMqor/PANZXwgQJ0uWPYNZngJOmZHRMmIx0rwSQAD2cNAjsasFQaP+Tje4sxZCt1sWgH0yoj05wKOz8gA0SuEpHJQnXGO10rqt+AyJuptIZbjovP6NyVeqXiI0G4bKSS8FqCdWUVLI0Rg7kldU4GLYjSl+2l8tGdJ4zmf86ZiXPxVTryzFzC+mcD1BIyxZhnULUtmv+1LXuZFdCFUmPJG3idYg0mUKAii1i5nBsg+B3wR40K2SnZz5d2oJBa6DQK4G5kCZUCH6BVjiX/avc1hhYA3XyKcbVyJ+kECxzhiuLNjfmO0417BtpBHVl66hzWrIJ9ZvCL6mUPm2WHp6H9j1jNgmblX7y9VmyWwnb1kMCPH76vEcAkiBu+WFjjr7RifdaYQNPmCTOLpkcOz2DBgVF4CZu2UJUFQk5Asni9usSE/ryj3YC9hx2Wle7fKa0dTKHVkWq4KZ3VWYEW1Z6kS7Ga8ENNFCDFA0XT0Ez6ebtFM8/wCDYMG0vEm9XFrtgqw3pfXA8//Hf7NujH/7tzfdP3vxj7/n81PzH2a/50T///tv+n3tLEVmjvw4PYt7svAzAg50WxLUzvCxlnv2s3gmYD1rJIUQOFb4/K/YzgWTsZ/YnJtVUt6r4WTH2J2gFkfwFHUWM4pX/TXxM/2oV9p36Wf2soKdzCrPmTZO0HaYLYEF57fo78ai5G7xD3WfHUSElhk0KM0ouADOyDNPHYfJXUiwyj8M1AwfSQA8HYWQtnDAekR7Sd8OpQ6SHAWCCUQsaLIUcB812VtmJaN/jm1KbBTeFKD7I5hbWuSHPILlTI5ak03ZNfiIDuTH64/A4e/AttEY5yA576Emu+AefqdTH7sEEzOnJ2xN2FqTDWxyKPQo7d7FYZIBDps1szytm8NTYvSBPdj1ywwfZx7mrq3j0Zeyc5Ajqq9CdJHxlSf7wCjtVoARDU+mtcN9BNSpIOIv/IudshAvdwchma8k7u25OA4L3qws3HQHxxtF0yTQGNKHVOPiMSZ2RXJGRowfYfg9OLvaTLOUDXnNCCpeA3Evl0rdrlG73yxq1G36MIIMCXq94D4/6s6alvWXa91ms0etn4XQRh8FRMyY+Zgz2xZhVyOK/8BwsSSAa6N74+hdoucVQSKBgxHoTJDwHhuc28nIixLzVDsnzgnc9HwT7mx8n3YbxSoCOwhVfQv1hWzRj5vJmzGRz9XRX5nUzZsLl2TdfHuVd3nyWFIRTH/D98fwUK64r5noHG/gtsPVroGIGtDvyFExOSY0V+Zg1skaCfnnkBKQT1wA1pTGpb+DH9NkNzoETFXramEG9B5ijkleBg8exDhZOa+nhlvDzfSRiY99CQM3DOMDHj3wjkdsh7vb1GxlXSQvXKF6orzatMGd5a52uY4WHBwo1KjB8aHe/2t5Eq1LO2u6CEahVatXdCcCsLh0Ml3Q461eclNKIBa8qC0lqzrSY4eUpJLXaawxOER5SY6muFUpiuUIfb21i36qFmPawSAbBfO9KW8vWgQZCnpy9IWqg2REQDdyQOnCg39X1/hsSUB5vnzGiluAsTJqDwTxtZAUb2rp4drCM34HEoZkKwaSWKuyN9/SBwsDjhirYq4vXcKxrNBSQdG0XqQF0YqzHzizBdACHObgGsXdVIaChcKAHlBCBXvkEp9O2rmZbV7Otq9nW1WzrarZ1NdfU1ayW1QRt008wu6dTJnG63Aj+s91TGobfFjhsCxy2BQ7bAocNFThYYSSvNuswDudrOELhETFxr27aywFuiHCHQCpWQ5PbG9vVC0N1jXAwDJZTcER3kKBpQrYu6yaECkx6mUA4eGIWTmHxP42li78+LvEfuqqEgX/5Qyz8qzuCrsmNCDB7JO1Fnx+SqHHmfoQ0Pb2/qGv3wYOgEFmKhki7eGgz40r+1hn7wc2z+vyWPJAUTjjfC2Ug1QMtWZBv/dT8mJwBJ2qugpbWhuzVHtOtZGpExuvdODoXVQPlNowbA3ezwUHfd9j0cLqbfLjySTpQTan7CfoRjW4+n9KS419QkpKi2mepTaqxdL2DeRDG1b1rhzsRfI764xZ2AiH043nsVkf5ZOtZR69I97tnH/4hLcM/uFn4B7YJ/0AG4R/YGqR5fi7M78oanSkYaew9vqmUO0se3fmK7GuFGw9DrNd0kBEXtV1Xbkc+5x484KPkamBZ7CW8TEklvbxaGCneq5o1WHZXOqEgU2lpQ6vjcGcv3psMXneCiAZiI33ACnh3Vukpryi6BXGrgG7nULqLvOZmZjfEF6MTY/iS0iWQSNzMMCKc+sne4O2RZE/46UFEWuRwFYay0smrXr1jNlphI/pzl9lYjbnLdoM43IVoQrA/d+H0Af9b6dEsPoq8xQsPNkSKkyne+QI54F0L40CVbvTBDtlrrdmbSrUX5vYZ5OaIdhxpIXrFJ/RDH2rYJRWkWjdGzwyvY62jlbWs+Jr7fVeRb2RxizV+XeZHQE2u9LxuBiDXkuM2sA2H2ycG0H/v/SYX4Z7TdNXpHpPBko8O9w+e7u4/2T18fLH//Hj/yfHjo+z5k8f/7Pvp8dqrIvtd075AGOz05QCJo8OjfkIXmpx3GOp3MRwOknDcBZELn4/xJnh/ky1KSkvpGoGggGgGcRefXT3tLrV0x/FSy6TZAONsavQC6qatCDUbhETYohCvbfgsNv6pMBFKDcqcITYu1eyDT+cc3FT9YKQCitBYlOIEl0HrMuWswWLuzXUt9njlr4wIKKfxelK175JHN6ramOcINcRwuVPoF1ryHC7ZBZ3ZyCuNROUGckVBVUqRJ9dFwdExLjYIF/+CXb3YhLLULVxrAuU0XC1ZU3F4E/KBMcZL5QXsIkWBQPub6QATOtjVY1/0DN/yoKIgYo5DUBasZwBJahWq3KCuhUBSVYpiE6JiNokzOYHkhNwIF/0w4L3pPPvCjintjybWYpshCFnEcLsZU9Z08NgkCWpjllcS7+AKr0IUkKLxWZoXim048NgORR8FTvH0LGh7pzvsZTMZe5MHrmODTFBPNOot4JMAT8+YM/JKQj/fMVNwkRTUIvhKAwIqHaQ3CG6g6+d0GXNp0qGOeTbN8qyYfIKVIps7bKj1MZWTKpapQco5rrEO3UNCu9owThLtoD1x3j25YUucsPN1GTldfWVB3RnCQgGTKEogKrXpZ80YMYOEUzCoIf0B7/Lu3oesDMOmMqY4ghXoM0xzbZJbgaGPy8WLM4LqY53ksKKUXiNyISGRiAgklcRWD+f/eEspmo9saJlPQAFgh0vGvosdW0Ie4WAk6kJbLZN6U08PgrmSmq5suHwQpQLlwEA3gzbEUhGSE6ZmOxHeDgggLKdOwAYs1AriNvT4wp/J+g8h32GhE0Gk8w2gB4LNrgyRzoME0nlvAMhWaS3OgiB2GTpSAU/80qq8O174nU5frwPWkbZrxdGBhN3rl3EXFRFxQmSQFx78XphC/2YTsPMUSC1mRc0V1FRQzjsQGkq6PvrLiUieEVBp8QQFLUacZlcSpgt1x53XUbFcGMd79UpBVpk4Rgm5VwEmXW+Vcydm2iy9sKI6NetkVTGhbIsFT9xdV3ECBCtlVUWxwZvG6MbAzVbV8hOkEUnyO4ike5lDyPV02Z1fmKg60KMfBUw9lbNWt7Zaem7GbwgkXEIMKi0a7Rgx4CDGx4yHdngo3ltsogdNlOEW4n90lKU2immHEIYsD+4ewinw/SSjB1S6GpkMkzAVFGUSVNhfrc8S88e9SSabCci0SebRmoA7D1QWaI7YXrq7ro8BNBm8x5sq6/or/ABnUNeVc9BGIevQb8+BvXXwvJ/27Sd1C2b34RSSBx5+ts1k22aybTPZtpls20y2/0aZbLK5BYf1h57RMJMs5JHR6wz2NfDVSpiWnZ5dHYEyPj27ehpgiFVd+9kS0NZlv1ER1i0IXOf0OqOqsfso9r5P7A51SNciAWVBN0xx27xy27xy27xy27zyD9e8klqLrHrQwqMbXGjBHQN3D6/6Y4KYxN+0WXOfENhChBxcJ5TrqsILn9eHeWOIt5TgmVZFwp1Ylw2ek+TqxjA2WKkU3P4Ed4Fo5qIWhlcbbLfxKoyRiidNBmBA/5EsUd3jHeDQ3o1AMWqiUVBdJFwJgZ4dyzi0rgGPJIarrK+DnRBA3H2FxguWQm+fhDme86Pyyf5+2SPGRrbT6P3q/glca1qlwIsQMB5OmbwSfgdW8cbQZY90VOZf80uIOjjo6Wgl3o+fCLYIGlkoKX1EKadV0tptiE68ZiL47A2sE3SFECqHGUhroVwO/YIAy4gCJqCgz0kuOve9D6RHuOFmeImuFiu6ZAZAMDI7utesVLNKdHeEDVa0ePxMPBHTUuxz8TQ/+vbZYTEV35b7B8+O+MHTx8+m0+eHR8/K21oUPMyap0qO6EkOxmT/r0mnZWrNh9J2vA8C1neFomXHi8Ut1Em6hY7k6Y5TARavO4DcdMwXDANeJ43T52IZuknFOKWM4Tf4H91IEXcb4N3FmRg7gTAnRFw8esBkhYQsrGkLM6fP6M4T0yowUKLGgXiTXc++QFAK13STZVMOVcI0lZXUAKrixl4AumSvKg4teCiGlJAZ1RbV/gY1DT/nVWshlJSeihiGFv4quLNDENJChmkhSt5WcMlurpsYBo30AnFK3sgIU5YQuQowaD+KYsjqIp3DLu2ZHl/bJMb4kIz9gu6YQfiRt2hO/5J09U/aXTBuYOxQWI5qf52e7QlJOItpFYeLUAHiNZIS5VdXFIxSs49dnxnHne4CqF0fj9hxYNJb+MktjNFbDrIMNrEi/04ZdSsLEmMqC37jqnQyDNt26EtwSnFK3hbOX2++YvPQbIABeRhwSI3H2WGWdjbwoZee+dc9ucH6828NDL9BIC7EdhAr7wjYoyguodaHlETcbom1pZEiCrh9kREhim1tI0JfSETIrwc5jhIm+heGhTxK27DQNiy0DQttw0LbsNA2LHRDWAiVxR8uLERYbzwsdHftvpnY0Jp5bmND29jQNja0jQ394WJDralSx8D7d69v8Qq8f/eaTtvhJkpm2wZEK/IG1LdXkGePaa4G1/L9u9fULY/eDPoA6DU1gl+CQ7bQC6glAId4DnGTMR2WxlifRd9rFsT8XTwA605zD7dpXtLhnMhtqnHs1r8DvY7JKZXleifZEKcKT/vol7WMIz1rvvRJ0pTECxaBb+2HdPVJ5dWyq5MNnoEIFeabeZcvFCVyK8aUXR+1tPemzXRQnBM6xZMjYGAN9qfQo2tp+KzunBgPTtkzbYJ1Hm6/46Wj1hyTrycJoZ1uUupegK/560m4nITuYkFSBKSz0ecqMz8tETossXd6yRrWk8pysNgBukzH1VomvhfM7w3DMTDi4ZrADOBNILdb4IWsSXdzCQFB6LjoTAtBVpAHlDkenD99x1NqxiTLnnbr7pb/+Ojo8Z53r/7l1z/Tc//3107329KGe2w2RNXRe+UvuxFFdz8QsggVkqSzjbMkSHhCoox0qWJhQNccdJz2gini7sSmqGExkf5G8CDGcHl4DnVe6EH3MOBTaamc+Bdo1hxT+UNrWBBsPeZNVzPWb8XPIliO8U7wLwdExz3Buzbye6+FBS665ufemjfc2mQlH3rNzwh82Mu9q/I6HNymDKQzvNCnN3Yig4hAO9ktp4216NzlxDEY8ujo8WDjHh097o2PZV53QOA+9MBoFA5A/Br9Fkgi/wtEPdVs7RwIJtzPw3ZW+Gogzv+C4lx8hOYcIrnGIR0FS1W8MiVzEhQAm/xlgpsxWlqMujYluOOn+A78xuEbTKgIb42TwfADStWIEONtSnXjOnwQdf/mhL5eCcD1IsxsKtxCiE6jw6AQ2c758EjvDaRNre05Qr+W93ZQkKSrBCIVCpcFmxyvVb0e32tEUm9mYCtv8Jz1nsCvTC6tNoydCYJFHP6+IVB2QeZ2MIzDbuifYmK4DF/1KgiUdiWueNTLZJz1w2d0HSHwD978Bn4gAU7m3pkEnkjoN4ZbIZzl/AU6bs4h1QAOw6F8tTH6ShaC8f/H3tc2N25b/77fT4FJpzPJHUW7zjS9vb0zvfV6vakn9tqxvNn7joVESGJNEQxB+mE//X9+BwAJPkikLEK7ndkk06ltCed3gIODg4PzYBNuzaFI24zeJs0s2R4KQyzuL+kC+S/yfvwXOD6+tM/jm7uj193x1Xk6vlonhxJZwFf29uNodlb9doB+12NYLV/FZeI+b6oL2eoV5cliwN2txbMtLbSWj6YNKUpZ2LgR3BzcepM0xynPYC0UJVRrXwxXyaEoQ7Tqa+NlJxtqzSWJbtY2MGC7sHgBVE1dS05mfMmz6Jh314+JWVAnkMeCDLpBXsnPURzz1z9P37Dv9TT+X3Z289FMKarPnfwUnOhGlbZG2g/sNE1j8UnMf43y13998zPagf1shmbs+1//dXd1OdHf+UUs7uUPzEQzvT75afqGXcl5FIvXJz+fn/zlb2aeXv/1TbNE7Lei09+KTn8rOv2t6PR4Raf9Qm1EbO44GqAFX/2I+fg7mwtqwWOsBgQ/v2qM+w8idmYdDwu52Uhc9XlpK5TXBDIjURoDFzxTIPpV98Gtz4NG24Qu5nf2QjD81UYGsimKdn2uovX0wDyOSrcm/Gl/10CbH95EK6w55jTPClEfXfNiPqmHlfP/iIU1Z/UPQS8n/zC/dGaWVsz2mcKtyxBr8Ee97M23mybSViLn+JIZzxrp2JI8DCNT0QdWOhbQxtQTHXMLra+hi8aJCN+2gjtgVdCckGs7NC1kSzraiwghclXuzvWjQTvFrj1wp4w2Rzf7aBHLIqw20hl+tG+IFC3OTcJYx0xcmb9q79+i9lUFd4AIbWoGD8OAPhDYIW0RNpm5W63GM31hmmYSolldzEt9YP7y49OrnYvlGp7mK5CXX6RcxUJzbFbwT+wUk4n7I5Nx6G4aiwnwpyUwmqWe1ej88M61dmjYrJIqIW43Gfv5arb2pjRAwBq0dkjZNmomuSdwtuFuYuYLU+cLQ2kZNR/FUf4cDFCuu781lKqRtKEL15LyoXQyiocbRKP20S36IER1o6xSCO/szx2bS/8NVXnzZlKF+R62toKjINDnA6qexwpTyZPFWmaW3o+lMthy7Jawuk8P9yvu18yJ4QagdE+TM1XdX+lcji2kNnwl9qeGb7nHwZ5UG98cRvTl5GI+F7Fi7E/s7vrdNbpkP8Jht+EpDBwl/p8zbIe50WNy9By9F5grpiFMreTivKvkFt2nuqX2AvaCI63GCYuv25zDqSOg+H2neJoTAzU1rT2J/iNlToxYqOnzJp6az+k+EQg4wDmUyOTH6psNJ6uGvlvSty9NzRNqh5hLGQueDJzeZTUj9PpWLXubrlTTeRHFbZLtFS0P7u9O/vbu5M3/+W4YnOsZIwquP7Zc9ftijkuwTl8xa/+r+7uOgau/lwZO3VqpBq2slF5NVn2pV5tVH+1d5+Z0pzJs7toXbCBnBlJpmjJ3kiqicDRKNzJkHy/etUUI/6tSvhCjkapGbBNDxsioM5hYV1GbmFZR/apwGCGjczc8bVOiSEw6KkYj5wzZTbNSC6POZznslkmtyHaftIfT1eMaDWM2c6VeTBHubt1iK3SXiqW8Q3Qpgmrs/bSAeBp61hsK01bh/q7z3nCsXSUVw2f25wH+lSGuFYan+Y0Mi1iUfRtMXjJSbJcUt0GfNycqfXlafcP4PzC/SuQTe8MsE0vNZc8EkImQzX67tDUgo6TM0TVfoRHLA8+WIrC1bHHxBhP44/R/bb2rlvgNTDPLHTNmQ2hcf5BhjR77yTdLybdOzMEQd1CNcKcp1wbrbNyuzTMUvh1vBwc1AI0V2B/BqVk6A8BZXwcPexStLPfScNgo9Udcyfh3V7PZb5ffdQv5VYTiLnKZu4JkiVTjz5/1qBAZV1BqU2/Idu/3Ldz+m4YtX6mVO+l6yolTOcdWtDewCmqfLwz7CEUrRlDjrq/FDltDOxeIrWO5nLZgWH+CM2B7hnroly6ptmDY4SfG1stlpZhKd2HXNLkYIbgicwNa+iarB3Bz0spS9QhCyosMuZHzZ/bPf87Ob38/v/1wenU+7UTmD1I1cYjeRiMDG7hiy4rYT3QjM5cqj5NmKLi6u8LErhOECfMolhADU0GlNZ79PIJ4bU2nR2Tfu4PRMIpJZ1Mxtv2pZAQ2bzIZFovcDtxck24U9EQzPoYyA9dFMKl1Crm9u8Lr6ezmpBuZ0G778bGZgYfNj0hWEMhdYKIkFyuR7QfmndV4msB+oIxgirATT/sCPwDPpzIjoSKO8Mb2dujBtuZhFogEBp0HeKe6ejr2qeN01ddhnaNhSG+ZuLTQ+f3jLeQHanmLdUP2FjK9z24+qoa66UazERuZPU9tZvjUrZHB2JYgc8a2F9YYCPnGpqJrAIOwUqm6AM+IjeFaXZoGgrizvZtKaXvkrQZOFX13uQ859f/tDtQwkzKhcESZmgIrvPQmoY39XovF/dCjv0g7J+ngjWkK6iJVJbU2cwWUJwq2XVjFu0870dE3pmGh/bfTYrDI9SB9Z0a08lQ1BrFl8aIE16MNmchiIRPrzmwiTBFuMD7AW+qZlGdRyuzgwMrZ7Pzy/OyOnQxDZ2oLf4WGnUF2LMvCkNsFZde5eRCUxnk5AIo/DWa2Zaf+coEUKahPlak7cbhAz7SYMhUli/2gUOTDdBFzpcZbm1mOtxaeOyVkSW2SCYEcN8Q7yiUL0XspX6QTlsdqohXDhJrQySKfvKqNyZhRwDJjRXKfyMcy6qviZpEWB50Ji7RoHAUI0CzyKLZNo+tHJNQHLg+cbaKkyMXEtIlqWDzmiQDZM3C3ZNbLNPQY0YNWz8KHistFaPlAihObF8hfMlSmnQjUH3Fg3YLpIu/EoRY8FmGwjCXPt9goqcgQArcfWloCVb20OPdzC6kbM0WxBVEYi6NjvggRJnLzsSEv3UCpw5edXvHlJ7jsOKbxbOXB4tcpBQeaY3aQxv4zDQNjscxNTr39IJx0xcbU1FOI0m9cRXbtqPuo7P07gr47j2jGNCB9uW0DpcZyXTgrVFAQG1tpewRclABY+rWoBW8/CH/mzEAAerr8QGisSTcAJ15oBPK44NcKvmqRrvIwoyTHqsyRsJB3I4qSRSacQg0HHwB2PJutShmqGlg3gpirPKAPjAThkquc0YCV77/yFj6aGpa2/jlbohQzkenGlyFhEcXId1zr9wdZ3elt41I4zpG+qfvizW2TQFzC5mKJDM1MkG2gPYpz3DRawxpByOAxPIBn6OyjHxY3TjowMVDlOC2jrFxTk/5K/EOjq2IjQhQ2UaIqG1P9A8OwnACaQaHYyZs3f56wIonx4AYCVm+wxfMi3iap+FuUrHxdvA0CMqq1V7eFG42OifHpqya4KAnFk1CHnZR6DOeg5AkrEkiDHh8tCDeRQsF5/QumitVKKPPeVHXcNNKI20j0GRdNmdUqrJQmL04zGgh1WCK3RFvfQXuEI23aSbj5bj3iIbKDqr/D06ysVRawMWprLNQxDrO757SJ6Ozy4+zu/Pb8HQ6xD9cfyp+78ajos5jez0dS0LPocxOPLNtTNESWLbvEuxul3k7TIoU/U3k6U8zoFq67hZ2bfOkWNff4SRlm4P6zQCiiXLJ7IeA0Y1G+kzE6zjX9sZ0gNTbgfwAtpmltcUIYQZ6KP9DONn8OtAmoxpPbMz2gnWpLiKVo9IHK2OZ9vKY1daEtcursxh0lR0Nu72bjwKYSEaE/0NYBHcGiETDwcokceEuZRUmbgd2glRD3vjYkjb11RqliB0lDjxirBU+8QcTYh0PEngzA7pi734goxkYBr/vDYfKHVbCoQpvtv3oau0zbHpinDyKDaxKlZnUdZas3XUk9YEsBcLRJ+SI/umHe5i0T8JA7XssuZqnokcNxa9wBezLaIFVp+wX1BSt1vp2LXOY8PoAXE/tnpovGgctZ1X6tF9H5AwRalaGH7j96u7OPphpUxpMOyd9mntVtkdJW8fEsbcpQGGPI/GLH6jagHVmcL80jeg2FZSMWfNkMLanZet1GUsoRoYPqTXLJ4KbPhnBuf/Kl0svx29xNEJ5Ev7exAVSlJFEIzI1MKQH3X+Jw+qrJUMxz3EwPunaaMRr+2UeOTiOYKerbY97M8VFGj0pD74kjv0C5N6ctaCra4CFKVoEp9eJrnUFGoWSTmcmyg2APNAq2mG7USIDuSH0CDGm3lwPiT+OBuuJP0abY1GFZQRoAC6FX2QOPp0ddy+qSlmbiIZKFYkuRL9YDMI68qJ/KeevHZPHooJ+DdIIeoqESTDBRofiq3IM2koHUg/nAIhbZvbmv14YFePMmmUoZW7W9NE21EB88VK2YR6wytCrQtIMoCQo1oh+iGUm1+72yWUMm5tmqSmtw/42Rwxo21XoXh/qTAT4ZoKWibhSjxmPxSnNmxhahtrb2AAgmj48PVIfAcx75j2663+hXW2e/LORmE+XgwgjUWsTlbRmVjiE68APqz7dGtFztZNlEwMUi0OSCONpE+egrYmoogKTzdKJJ7gaIZQsoXFyNrsExNtNj20m3ILUKjfLdkTPbVEssH/28PVh4VCBcpTLRNagkvRE9srShghKJMn+mRdBODh6iLC+OzICJuo/lI056g8AmZDHK7Oyedl1YaUqXQH/6e1vIQweSchf5Q1OSsAU/h+CCgAfLKBZjzxVS+6KckbJoTBRdu1qSaHPlgIgBkRoI3MPUljqp+Y5badw95niBh8rxwM1oAhiN+gJJXEertd3EKlpV1Q9H3seyWShME0OOACA44tqQhJ3oY/n4hcFDGb0Mu8Wdtx2nB1xiZ26P4gachlxQjLCtVt35yov/mrx380R2uVs0ZwRW3HdDw4AmswMB9IBP+9CY54SjuvuCyV2oGodlqTbGA/q7OQztPJWK6WWA+WNpcItw9Ok09n+UtAR0X7T6xhfgxqeXfiSgdZNPtTJNo6R54ezH5+8RezyMtrdKcMzJLBu6tBHjfkthTc+ieT1i7DHDJkpgzYaRctSC5SwVGV2xDsz1/bczTsNtQU4/UxVbPatpuAmkCpzPB/YTQ50PfJFHD8JqilXGkzzgGwwSbJGdrptlz4r8TuFWZh1OiaI9Iogi0xTZ97++/YEZDqa74OZikwYU4qJ8YrwTm5TdEZkeWPqhJiBfYFD6zoKNJ3jmAegS5LRfjTLUvt+oH4YB7YXYqq3Sqmz1UiZUk4tu/BO86usDxfymTL7bxtoqwL4MMsHDIJJflLMVexepe3YreMgurkdiDApIfCWcfQKWsVjLA8RnBkLGQZxat3gDyWEMNPDn7D0CQs+vL19f3rBbTdBi3g4VzusgFGg5GOCOSBooiHJhazWPDxYkmSbJ3kexIG3EQHIg3AqnSIoN2dYy8Qu3wumQ3BvumidhLIL7qKyd7h2uJslAciDcjXw4riiA4MsEIRUZIQ2iQHrfY5gcJPqQK4VdvL62PSpeBhaeRCWOilaTHAg3EwmvCa9/QdAkXyYKuQxWIj8q3FyylchfBlfHZR4Vria5N1yqb0AGyBc8oKfsLWC8JuPjkJNZc5NnPFFLkX0FHN0ZKCNwRbbUV8CStqMO4ScWySpfB3IZzHEdEKHmrdtEfCn0GvJLoohfvdUUGXGhLOjtWG29gi+/RSySw3dJjSe8UqZfEWNncpNu56413h7cfhVqoWR2FM1Q8vbllUPJ2OH6oc7VVySgmrXRJRTnd2BSRo1KVNtuzC/lqMYQuV7emhzVtyZMzb0W92DNpVkaAusfai61vtZYByCd88U9/JeJOVoy7a4NttUGORTq25KeBorm/iuhXiux6AdawIFADc2DfJ3JYrVOi9wr1CJ9faspsruS4hCwWG1zLfI5mVhk4+IYMocmSCvn6t6PJL417zFEoQcMueYDemwO1lEekPvCDyoiZd6118iUBakeeMbcX4joAUUv4MTKRBpHC+5vPUGT3RqaOrH5VtMcsLj4cqBEkge5PBLUGQoe5/KlIMnOQHvzI8G8s/T6gVK9oFRGSe5ZIZ6VhNjNME24SIuAgluDbXGCRzjudc0WBNT8efsJ33Oi49WObv4qwIOmr0cpKoCB2/736gf91tn/FlU9NliJ9bP61UsCbaj+xa+9guAQzHOEhPrHd3FNJyCR2xPmMcANhGSMMf9raqywPRa1fAE60qqWrzz7LmsJ9Cjw+kEtEbmFp/RMxvbd09vUvUeQFtoIZjIuHzaB8Yc9QB4D3IB5k9kjz0IRwsqRWehx0iwl2DagNABcJkQQ4/lQ5TyOPWLLhGAgxDShgdAoQhhpHAhXCOe+zi5CNwMtSlUnWgNCKZZFHAeUF+pv3oo4ZjOQ6J8xSo4MlOAZXdh9QboAGTYzZPphVREd/jBVYRwD5inmn5+Nh9cjoM/Pxqc7AJBcBTxN4+cgFRTuH/xRiEL4ASZX1Gz8md1oWuw30BoKEF7n56PAg1f5eSg4bT4u40KtPR7ol3JlrjrvNaVhS9u44/pHZ6+2w+D5vxoA2Z43gwoYcuyOho6y+/eBCDmootX84QMdJ0xtGDCf+s2CGqrflEhCz3pjJpJwqMKo4PgUKwfRr297QUUe7QjUWRhiQ8RyJQufB7Uevx+ICaedZ/JeZAHFuJMW8ILKRLlrYoyIMRAbBJHib1UAtnJOR6pPjL8QNXZdUdsHpLEvjgDwRgwBp1aBrYuB69EDLXHAHzwhVCv23pDDHelB+6lOH/aBSZrjeDBJhQyDCQ9qEEdLEYinVCzgEnz2AhAeVAZCrCI0BJqU90XqT7cQrEtNpF/BECSYs54BwY4dCkelsc9bEuGZEY2BgDzfkgjQ0FuSybkPcHzDKPSDSBPBOaVLPe2GZIunB+bNzw+mskS7obIbFBlZYfks6gUSWVmhjUjtCVvCJuNreKn9vvHcWjpDn3jMlYzCeWOReFPo5j5GYbGXIunX5AaYOs57qIGn9nwJhTvzQWQ+vQG3hsQQqz4TofRr0d+KUA605gEm8f0CAjzJ4NcPu1cpfAnJEP48ETZUgpWkhsCj5+qNUMqzkgAddmXo9AMje8+3hiArb7B6ACR1jPADwFJ7RR6U0PwHHZTg9og3QIMbCGUUIzTF43179tslO3MIDYOWieOhuxU/7gcwR40lLCdqXNl0V0+q9o5o2Xpa5mbZ/yDjJLUGi0wQZwh48nM6ubmtZ4Yau+W5GA5yKbMgFCrPdO1T7zDfy4y9q+j1AEVBmSOtN0jtv9zY+MhnlglyCrmf2+5dRYW9A5XBoPxtYAfTgJ1rsn8WMlnG0cJnCOBHosQspSERgLjKeZYuushthkpVoeBwLJtZ+rlAfVQiY2cVkd2QTCPGgEJkvT6G/G5aPs5AaehjDXkJgqMIPjkL9hT/pzy1MSGxyIIwXqDKgEgWzwHV8fQC9P/f3dgYEbQCfHd5xi410dfvQbQfstkTtD88rTZAGm1Le6R7qS2osrGhOqg2RzVMozSHjmZG/RCUPjQ1OnhF1mn6URvRNPwcWK6jHG4ahePV3656LVbj95BHgOmYEBArWu8/iX7eClW2ECou+/AkMhSjAvrw8eqUYdS9p0blPC/UeHWpUGKrUC0Y9Qb1v1/MLt5enrPrD5cXH877IMokjpJuu/LgymslFdRQ1IQmaBmGn5ZL/GibUSnqPhkpJp5QabblgGS2/BVfLqMEXQo3XN338UYbcboosqxd4/TwkkI0esda2GKRMrOluofhxPe2doE6HKjBUrYVctp9Vkh6gBp95m1KHX35Ilx80VFm3h+ssi4oZ/kAccQw2rPnqcx3e6G5wb73jNoALoo1jqQnwBidXVx3zKxlIpel860Pcix5GCz5IpfZSEAvJW8fhxNWmNYZ+kyivybikURA7W7lbcfYVef9ZUdUOaVazVZwdyM6uubhUPToqM14HNcndjfSL7h5XgL3yPunY7u8dJLxmQ1/Ggmr7ZSQbFWmptQ+/v+GP5k/s3yN1zbFlMjzdqAHgwUNqyEUVBN7EFPHOrT6W/of6biqn06HyIN4WvNCfZFi85+6ZhflYzn63tQ6LW20rE3KLsFvqIjjyZR9EM0a4czZ75iYu3/dnp++u7m+vmz1Rz2ZvmpNTxoleMI+8AJpR2ncH5v9aeznWtVId10P/bX23AqnIr6QcRyprU6nQ4Qb4alYfK0gWJ5F5jBe/FFEmagjfFyj5hRPqA1ha1AzhO5VsKW2P4Yan4lYyrQU6VLVpZHer1u46QaIRHe5XPqb6AoW4WTPEDoRlncI00c6z56Bna94lPQ0lPEoHdXQTkvSvbrd+FlwGvWlkLwtsR34oLmiAm3lvO97NvRgtYVBK/OBaFI9uZLmAPgWeqVaD9Pc5TBt1R2LBG0vtMuv/JzJpwYHvN0H+ltL6iO0pK4Wo5syL3KpX1tF6MkfVonDo8gEM8Ssb6vsbN6Nz2mCHI7ZB7ULWX8T5Ew++upWhqFrkqJNspfjDBR5RkOfeA0JmCKmYmAdbzc6C+zYVvWtC5mM6bTV1Km1DN0s6Brqtj+Wnxmu0bCYYsFN2gC6N9vfOhJCx0Jr1HwtnocKT6nO912cHs6uavzgMMvkY22unTOtS+ZbIxo2JozPURb2pNoyerjFmicrvB7jOTRf84RJdI2StauqZRvhJ+H8oBNSD9E8HSkz2fRbN1Qm0H6cKaFwX6CnMaMOzWNxbVx6OH5Zbz1KwR67uZDbC0HzQ10QdrYNIhzICh8PBuV9qzLve18wFCmgDZQRO5jMqtWeU4vBzBhBasJUsVjjEo85kxlHiyOIqX417TI8GHvgWcTnPZyQGZ/w2Dc3lk4HR6bjnWUI7Rlkhk+suVq3hv2PhDkLrhW6TuxkrhY94YszZ+cxIjSxh4H9teYp4alaS7zlSR2DOGkNbFzRlM5vm/lIw22eRavVdvcT8buJnkQYiKdcJOOvpNm0RIMZGhOm1jzT86AErh7lAm/BqbWWrxd4M/pO0pRvOZ7BTPmVewFYb2t4f7DBvogjhDibnlF7gUozucr4xisuQ2MvXJ4DAoZAOIayBxhX4e8F8Fg6HICaenwvoGPbEi10O1H4Pw2MKWEI6dPAQmueB25bpTpe/EstNpzGwj+9Ofnf01dNjpzAOyTHHWZ/1sdqGKLOX1FlwJSme5lZOWpvLd3tXDlWJeDtMOAgzD4Xfwj5L99/GChL87dQohvsKpOP+VqNNFfV7VR7yi2OR44oppQnoQidW5yVq91detU6i5L7Y0BU66xI7vcGmGdFYu6tRwBpqL1gIsukvo0MRTzegVtm8tG4Vv4spr+z9x8vLyfs7cfLX4PL619+OX+HC+3s4urmckskXiYKJajyynggP605zBPxAMO5nFDKipwLuEzKea1HDl5e/xK8PT379eMNa8XQMHZ6dnfx+3lwd3v6YYb/f/1hyj5c3/3r4sMv5ZspGmbPRTV+N8/Qyw/Czy1cj81Sntlu42B+wh7XEYKVeYKefjWI5enUHrc8rf7KZjc/dTNDOyiAUybAe0qRjscXkrdtq8FqA4AUeGKGXNfpOgxvVbX2KJgdcntiNosFw2At47BIO8F63yscrcxRgJgMyNZQpg1jOIfFQd111b6MPsRLXz5U27S8OtFfgs1TyIrZtQdjlDGy0NwUjilfiem2LI79IZ9WRoemBcdG4pquuln3YybxBCBLieqI98d/9uSYvmqy4gypxjKF3UgTXgNNXOAswJRQ+HbjocsdZbqJkiAstD9nQj47BGMVqpwbw/H+5nQUjrRQlRvFQT7tpOnvydEhTWGjmSuZZSS28zvFHqN8LYucNV98GUl4NwPjijg9Claq20U8Fyu+7VKc83zEWaw1E3cg1A0WozYk3k/iGNoaZ2I3wOb0+3nidQiQBWuMVFybOUmArTUyQU7humun4b9ogzz1KI+fzfMIpgPVpXZ7JLz6Icto9waf3/yT3/yT+/kn2SlTsRApNqz5Hek9xs1B5O6iqAmH1YK0cfBoR3E3Z7FcTeHQmFINk6Eq0vonur60hwE8f25uFutX6ToX66BRSCR7ODJwS3QA8gmsG6hdZHRtV7uw/MkWFpu2o0cLfC6e8j2h4goEo5A65zCqhDJ/dmVsUtntwPmXN2/esMWaI2K49upkYUKkDjOzaISGnxG/cyMSyCSiX+4TyovPdk7cwZqiE0p9UpDEYPLovFxLQAPJgSxfR2oIIKrZP92okZBoj6umC8OnhWTCogQ5ifbkQ86P+41epPxpPLQ2nWIH3m48GnXgYwKBwkbcVxOE6TLLWwUPm7fftYgybIss7wkwPZ4A9oeQbgU48nx+Kpf2hZj8LfWsIfo7EP7PANeRLOQ="
 }
@@ -0,0 +1,23 @@
+/*
+Package include imports all Module and MetricSet packages so that they register
+their factories with the global registry.
+*/
+package include
+
+import (
+	_ "github.com/mathenning/mssqlbeat/module/mssql"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/availability"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/cpu"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/identity"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/indexes"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/latches"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/memory"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/performance"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/schedulers"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/spinlocks"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/statistics"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/tempdb"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/transaction_log"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/transactions"
+	_ "github.com/mathenning/mssqlbeat/module/mssql/waits"
+)
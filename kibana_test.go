@@ -0,0 +1,164 @@
+// +build !integration
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/template"
+	"github.com/elastic/beats/libbeat/version"
+
+	"github.com/mathenning/mssqlbeat/cmd"
+)
+
+// dashboard is the part of an exported Kibana dashboard file referencing
+// fields and visualizations.
+type dashboard struct {
+	Objects []struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Title    string `json:"title"`
+			VisState struct {
+				Aggs []struct {
+					Params struct {
+						Field string `json:"field"`
+					} `json:"params"`
+				} `json:"aggs"`
+			} `json:"visState"`
+			PanelsJSON []struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"panelsJSON"`
+			KibanaSavedObjectMeta struct {
+				SearchSourceJSON struct {
+					Query struct {
+						Query string `json:"query"`
+					} `json:"query"`
+				} `json:"searchSourceJSON"`
+			} `json:"kibanaSavedObjectMeta"`
+		} `json:"attributes"`
+	} `json:"objects"`
+}
+
+// Field names in KQL queries, as in `field:value`.
+var queryFields = regexp.MustCompile(`([A-Za-z_@][\w.@]*)\s*:`)
+
+func TestDashboardFieldsInTemplate(t *testing.T) {
+	mappings := loadTemplateMappings(t)
+
+	files, err := filepath.Glob(filepath.Join("_meta", "kibana", "7", "dashboard", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no dashboards found")
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var d dashboard
+		if err := json.Unmarshal(data, &d); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+
+		visualizations := map[string]bool{}
+		for _, o := range d.Objects {
+			if o.Type == "visualization" {
+				visualizations[o.ID] = true
+			}
+		}
+
+		for _, o := range d.Objects {
+			var fields []string
+			for _, agg := range o.Attributes.VisState.Aggs {
+				if agg.Params.Field != "" {
+					fields = append(fields, agg.Params.Field)
+				}
+			}
+			for _, m := range queryFields.FindAllStringSubmatch(o.Attributes.KibanaSavedObjectMeta.SearchSourceJSON.Query.Query, -1) {
+				fields = append(fields, m[1])
+			}
+			for _, field := range fields {
+				if !mappings.hasField(field) {
+					t.Errorf("%s: %q uses field %s, which is not in the index template", file, o.Attributes.Title, field)
+				}
+			}
+
+			for _, panel := range o.Attributes.PanelsJSON {
+				if panel.Type == "visualization" && !visualizations[panel.ID] {
+					t.Errorf("%s: %q shows visualization %s, which is not in the file", file, o.Attributes.Title, panel.ID)
+				}
+			}
+		}
+	}
+}
+
+type templateMappings struct {
+	properties common.MapStr
+	pathMatch  []string
+}
+
+func loadTemplateMappings(t *testing.T) templateMappings {
+	esVersion := common.MustNewVersion("7.0.0")
+	tmpl, err := template.New(version.GetDefaultVersion(), cmd.Name, *esVersion, template.TemplateConfig{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := tmpl.LoadFile("fields.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := templateMappings{}
+	properties, err := generated.GetValue("mappings.properties")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.properties = properties.(common.MapStr)
+
+	dynamic, _ := generated.GetValue("mappings.dynamic_templates")
+	for _, entry := range dynamic.([]common.MapStr) {
+		for _, tmpl := range entry {
+			if match, ok := tmpl.(common.MapStr)["path_match"].(string); ok {
+				m.pathMatch = append(m.pathMatch, match)
+			}
+		}
+	}
+	return m
+}
+
+// hasField tells whether the template maps the field, either with a property
+// or with a dynamic template.
+func (m templateMappings) hasField(field string) bool {
+	properties := m.properties
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		p, found := properties[part].(common.MapStr)
+		if !found {
+			break
+		}
+		if i == len(parts)-1 {
+			// Objects and groups cannot be aggregated.
+			_, nested := p["properties"]
+			return !nested && p["type"] != "object"
+		}
+		properties, _ = p["properties"].(common.MapStr)
+	}
+
+	for _, match := range m.pathMatch {
+		if matched, _ := filepath.Match(match, field); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -91,9 +91,21 @@ func Update() error {
 	return sh.Run("make", "update")
 }
 
-// Fields generates a fields.yml for the Beat.
+// Fields generates a fields.yml for the Beat, including the fields of the
+// modules. The fields.yml of the module and metricsets are first generated
+// from the field definitions in the code.
 func Fields() error {
-	return mage.GenerateFieldsYAML()
+	err := sh.RunV("go", "test", "./module/mssql", "-run", "TestFieldsYAML|TestMetricSets/fields", "-fields")
+	if err != nil {
+		return err
+	}
+	return mage.GenerateFieldsYAML("module")
+}
+
+// Imports generates an include/list.go file containing the imports of the
+// modules and metricsets.
+func Imports() error {
+	return mage.GenerateModuleIncludeListGo()
 }
 
 // GoTestUnit executes the Go unit tests.
@@ -15,7 +15,10 @@ func init() {
 	systemTest = flag.Bool("systemTest", false, "Set to true when running system tests")
 
 	cmd.RootCmd.PersistentFlags().AddGoFlag(flag.CommandLine.Lookup("systemTest"))
-	cmd.RootCmd.PersistentFlags().AddGoFlag(flag.CommandLine.Lookup("test.coverprofile"))
+	// test.coverprofile is only registered when running with coverage enabled
+	if f := flag.CommandLine.Lookup("test.coverprofile"); f != nil {
+		cmd.RootCmd.PersistentFlags().AddGoFlag(f)
+	}
 }
 
 // Test started when the test binary is started. Only calls main.
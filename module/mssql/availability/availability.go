@@ -0,0 +1,168 @@
+package availability
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/prometheus"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "availability", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("availability", fields)
+}
+
+// The start time is converted from the server local time to UTC.
+const serverQuery = `
+	SELECT
+		@@SERVERNAME,
+		CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)),
+		CAST(SERVERPROPERTY('Edition') AS nvarchar(128)),
+		DATEADD(MINUTE, DATEDIFF(MINUTE, GETDATE(), GETUTCDATE()), sqlserver_start_time),
+		DATEDIFF(SECOND, sqlserver_start_time, GETDATE())
+	FROM sys.dm_os_sys_info
+`
+
+var fields = mssql.Field{
+	Name:        "availability",
+	Type:        "group",
+	Description: "`availability` contains the result of a login and query check.",
+	Fields: []mssql.Field{
+		{Name: "up", Type: "boolean", Description: "Whether the server accepted the login and answered the query."},
+		{Name: "login.duration.us", Type: "long", Description: "Duration of the connection and login in microseconds."},
+		{Name: "ping.duration.us", Type: "long", Description: "Round trip duration of a SELECT 1 in microseconds."},
+		{Name: "server.name", Type: "keyword", Description: "Name of the server, as returned by @@SERVERNAME."},
+		{Name: "server.version", Type: "keyword", Description: "Product version of the server."},
+		{Name: "server.edition", Type: "keyword", Description: "Product edition of the server."},
+		{Name: "server.start_time", Type: "date", Description: "Time the server was started."},
+		{Name: "uptime.sec", Type: "long", Description: "Seconds since the server was started."},
+		{Name: "error.class", Type: "keyword", Description: "Stage at which the check failed, one of dns, tcp, tls, login, timeout, query or unknown."},
+	},
+}
+
+// MetricSet checks that SQL Server accepts logins and answers queries.
+type MetricSet struct {
+	*mssql.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch reports whether the server is up, with the login and query latencies.
+// When the server is down the event tells at which stage the check failed,
+// and the fetch is counted as failed.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Probe(r, m.probe)
+}
+
+func (m *MetricSet) probe(ctx context.Context) (common.MapStr, error) {
+	fields, err := m.check(ctx)
+	if err != nil {
+		addFailure(ctx, fields, err)
+	}
+	if m.PrometheusEnabled() {
+		m.Publish(samples(fields))
+	}
+	return fields, err
+}
+
+// check logs in on a dedicated connection, so that every fetch measures a
+// login, then pings the server and describes it on that connection.
+func (m *MetricSet) check(ctx context.Context) (common.MapStr, error) {
+	fields := common.MapStr{}
+
+	conn, err := m.Connect(ctx)
+	if err != nil {
+		return fields, err
+	}
+	defer conn.Close()
+	fields.Put("login.duration.us", conn.LoginDuration().Nanoseconds()/1000)
+
+	took, err := conn.Ping(ctx)
+	if err != nil {
+		return fields, err
+	}
+	fields.Put("ping.duration.us", took.Nanoseconds()/1000)
+	fields.Put("up", true)
+
+	var info serverInfo
+	err = mssql.QueryRow(ctx, conn, serverQuery, &info.name, &info.version, &info.edition, &info.startTime, &info.uptime)
+	if err != nil {
+		// The server is up, the login most likely lacks VIEW SERVER STATE.
+		logp.Debug("mssql", "Failed to query server information of %s: %v", m.HostData().SanitizedURI, err)
+		return fields, nil
+	}
+
+	fields.DeepUpdate(info.fields())
+	return fields, nil
+}
+
+// addFailure marks the check as failed, with the stage at which it failed.
+func addFailure(ctx context.Context, fields common.MapStr, err error) {
+	fields.Put("up", false)
+	fields.Put("error.class", mssql.ErrorClass(ctx, err))
+}
+
+type serverInfo struct {
+	name, version, edition string
+	startTime              time.Time
+	uptime                 int64
+}
+
+func (s serverInfo) fields() common.MapStr {
+	return common.MapStr{
+		"server": common.MapStr{
+			"name":       s.name,
+			"version":    s.version,
+			"edition":    s.edition,
+			"start_time": common.Time(s.startTime),
+		},
+		"uptime": common.MapStr{
+			"sec": s.uptime,
+		},
+	}
+}
+
+// samples exposes the outcome of the check to Prometheus.
+func samples(fields common.MapStr) []prometheus.Sample {
+	up, _ := fields["up"].(bool)
+	samples := []prometheus.Sample{{
+		Name: "mssql_up",
+		Help: "Whether the server accepted the login and answered the query.",
+		Type: prometheus.Gauge,
+	}}
+	if up {
+		samples[0].Value = 1
+	}
+	if us, err := fields.GetValue("login.duration.us"); err == nil {
+		samples = append(samples, prometheus.Sample{
+			Name:  "mssql_login_duration_seconds",
+			Help:  "Duration of the connection and login.",
+			Type:  prometheus.Gauge,
+			Value: float64(us.(int64)) / 1e6,
+		})
+	}
+	if us, err := fields.GetValue("ping.duration.us"); err == nil {
+		samples = append(samples, prometheus.Sample{
+			Name:  "mssql_ping_duration_seconds",
+			Help:  "Round trip duration of a SELECT 1.",
+			Type:  prometheus.Gauge,
+			Value: float64(us.(int64)) / 1e6,
+		})
+	}
+	return samples
+}
@@ -0,0 +1,73 @@
+// +build !integration
+
+package availability
+
+import (
+	"encoding/json"
+	"testing"
+
+	mbtest "github.com/elastic/beats/metricbeat/mb/testing"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/module/mssql/mtest"
+	"github.com/mathenning/mssqlbeat/module/mssql/tdstest"
+)
+
+func TestFetch(t *testing.T) {
+	s, err := tdstest.NewServer(tdstest.Config{
+		Logins: map[string]string{"beat": "secret"},
+		Results: &mssql.Recording{ResultSets: []mssql.ResultSet{{
+			Match: "FROM sys.dm_os_sys_info",
+			Rows: [][]interface{}{
+				{"SQL01", "15.0.2000.5", "Developer Edition (64-bit)", "2019-06-01T08:00:00Z", json.Number("7200")},
+			},
+		}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	tests := []struct {
+		password string
+		up       bool
+		class    string
+	}{
+		{"secret", true, ""},
+		{"wrong", false, mssql.ErrorClassLogin},
+	}
+	for _, test := range tests {
+		ms := mbtest.NewReportingMetricSetV2(t, map[string]interface{}{
+			"module":     "mssql",
+			"metricsets": []string{"availability"},
+			"hosts":      []string{s.URL("beat", test.password)},
+		})
+		events, _ := mbtest.ReportingFetchV2(ms)
+		ms.(*MetricSet).Close()
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(events))
+		}
+		e := events[0]
+		mtest.CheckEventFields(t, "availability", e)
+
+		fields := e.MetricSetFields
+		if up, _ := fields.GetValue("up"); up != test.up {
+			t.Errorf("password %s: expected up %v, got %v", test.password, test.up, up)
+		}
+		if (e.Error == nil) != test.up {
+			t.Errorf("password %s: expected the event error to be set when down, got %v", test.password, e.Error)
+		}
+		if class, _ := fields.GetValue("error.class"); test.class != "" && class != test.class {
+			t.Errorf("password %s: expected error class %s, got %v", test.password, test.class, class)
+		}
+		if !test.up {
+			continue
+		}
+		if name, _ := fields.GetValue("server.name"); name != "SQL01" {
+			t.Errorf("expected server name SQL01, got %v", name)
+		}
+		if uptime, _ := fields.GetValue("uptime.sec"); uptime != int64(7200) {
+			t.Errorf("expected an uptime of 7200s, got %v", uptime)
+		}
+	}
+}
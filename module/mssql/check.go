@@ -0,0 +1,102 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// ConnectionInfo describes a connection established by CheckConnection.
+type ConnectionInfo struct {
+	Addresses     []string // Addresses the host name resolves to.
+	Instance      string   // Named instance, resolved by the SQL Server Browser.
+	LoginDuration time.Duration
+
+	ServerName string
+	Version    string
+	Edition    string
+	Transport  string // net_transport of the connection, TCP or Shared memory.
+	Port       int64  // TCP port the server accepted the connection on.
+	AuthScheme string // SQL, NTLM or KERBEROS.
+	Encryption string // Encryption of the connection, empty when unknown.
+}
+
+// connectionQuery describes the current connection. CONNECTIONPROPERTY does not
+// need any permission.
+const connectionQuery = `
+	SELECT
+		@@SERVERNAME,
+		CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)),
+		CAST(SERVERPROPERTY('Edition') AS nvarchar(128)),
+		CAST(CONNECTIONPROPERTY('net_transport') AS nvarchar(40)),
+		CAST(CONNECTIONPROPERTY('local_tcp_port') AS int),
+		CAST(CONNECTIONPROPERTY('auth_scheme') AS nvarchar(40))
+`
+
+// encryptionQuery needs VIEW SERVER STATE, even for the current session.
+const encryptionQuery = `
+	SELECT CASE encrypt_option WHEN 'TRUE' THEN 'encrypted' ELSE 'not encrypted' END
+	FROM sys.dm_exec_connections
+	WHERE session_id = @@SPID
+`
+
+// CheckConnection resolves the host, logs in and describes the connection.
+// The returned information is filled up to the stage that failed, the
+// stage is given by ErrorClass.
+func CheckConnection(ctx context.Context, host mb.HostData, db *sql.DB) (*ConnectionInfo, error) {
+	info := &ConnectionInfo{}
+
+	u, err := url.Parse(host.URI)
+	if err != nil {
+		return info, err
+	}
+	if len(u.Path) > 1 {
+		info.Instance = u.Path[1:]
+	}
+	info.Addresses, err = net.DefaultResolver.LookupHost(ctx, u.Hostname())
+	if err != nil {
+		return info, err
+	}
+
+	start := time.Now()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return info, err
+	}
+	defer conn.Close()
+	info.LoginDuration = time.Since(start)
+
+	var port sql.NullInt64
+	err = conn.QueryRowContext(ctx, connectionQuery).Scan(
+		&info.ServerName, &info.Version, &info.Edition, &info.Transport, &port, &info.AuthScheme)
+	if err != nil {
+		return info, fmt.Errorf("failed to describe the connection: %v", err)
+	}
+	info.Port = port.Int64
+
+	// Without VIEW SERVER STATE the encryption is only known when the encrypt
+	// setting of the driver forces it one way or the other, which is not an
+	// error by itself, the permission is checked separately.
+	if conn.QueryRowContext(ctx, encryptionQuery).Scan(&info.Encryption) != nil {
+		info.Encryption = encryptSetting(u)
+	}
+	return info, nil
+}
+
+// encryptSetting is the encryption forced by the encrypt parameter of the
+// URL, empty when the driver negotiates it with the server.
+func encryptSetting(u *url.URL) string {
+	switch strings.ToLower(u.Query().Get("encrypt")) {
+	case "true":
+		return "encrypted, required by encrypt=true"
+	case "disable":
+		return "not encrypted, disabled by encrypt=disable"
+	}
+	return ""
+}
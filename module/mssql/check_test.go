@@ -0,0 +1,103 @@
+// +build !integration
+
+package mssql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/module/mssql/tdstest"
+)
+
+func TestCheckConnection(t *testing.T) {
+	s, err := tdstest.NewServer(tdstest.Config{
+		Logins:        map[string]string{"beat": "secret"},
+		WindowsLogins: map[string]string{`CORP\beat`: "secret"},
+		ServerName:    "SQL01",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	tests := []struct {
+		user       string
+		class      string
+		authScheme string
+	}{
+		{"beat", "", "SQL"},
+		{`CORP\beat`, "", "NTLM"},
+		{"sa", mssql.ErrorClassLogin, ""},
+	}
+	for _, test := range tests {
+		host := mb.HostData{URI: s.URL(test.user, "secret")}
+		db, err := mssql.NewConnection(host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := context.Background()
+		info, err := mssql.CheckConnection(ctx, host, db)
+		db.Close()
+
+		if class := mssql.ErrorClass(ctx, err); class != test.class {
+			t.Errorf("%s: expected error class %q, got %q (%v)", test.user, test.class, class, err)
+		}
+		if info.AuthScheme != test.authScheme {
+			t.Errorf("%s: expected auth scheme %q, got %q", test.user, test.authScheme, info.AuthScheme)
+		}
+		if err != nil {
+			continue
+		}
+		if info.ServerName != "SQL01" || info.Transport != "TCP" || info.Port == 0 || info.Encryption != "not encrypted" {
+			t.Errorf("%s: unexpected connection info %+v", test.user, info)
+		}
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	s, err := tdstest.NewServer(tdstest.Config{Logins: map[string]string{"beat": "secret"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	db, err := mssql.NewConnection(mb.HostData{URI: s.URL("beat", "secret")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ping := func() error {
+		var one int
+		return db.QueryRow("SELECT 1").Scan(&one)
+	}
+
+	if err := ping(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The pool replaces a dropped connection, at worst after one failure.
+	s.DropConnections()
+	if err := ping(); err != nil {
+		if err := ping(); err != nil {
+			t.Errorf("no reconnection after the connections were dropped: %v", err)
+		}
+	}
+
+	s.Stop()
+	if err := ping(); mssql.ErrorClass(nil, err) != mssql.ErrorClassTCP {
+		t.Errorf("expected a tcp error while the server is stopped, got %v", err)
+	}
+
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := ping(); err != nil {
+		t.Errorf("no reconnection after the server restarted: %v", err)
+	}
+	if logins := len(s.Logins()); logins < 3 {
+		t.Errorf("expected a login per connection, got %d", logins)
+	}
+}
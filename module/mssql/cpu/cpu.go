@@ -0,0 +1,173 @@
+package cpu
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "cpu", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("cpu", fields)
+	mssql.RequirePermissions("cpu", mssql.ViewServerState)
+}
+
+// The scheduler monitor records the CPU utilization once a minute. The
+// timestamp of a record is in ms_ticks, converted to UTC from the current
+// ticks. Only the records newer than the last one published, @p1, are read,
+// unless the instance was started since, at another time than @p2: the ids
+// start from 0 again after a restart.
+const query = `
+	SELECT
+		rb.record_id,
+		DATEADD(SECOND, (rb.[timestamp] - si.ms_ticks) / 1000, SYSUTCDATETIME()),
+		rb.record,
+		CONVERT(varchar(23), si.sqlserver_start_time, 121)
+	FROM sys.dm_os_ring_buffers AS rb
+	CROSS JOIN sys.dm_os_sys_info AS si
+	WHERE rb.ring_buffer_type = N'RING_BUFFER_SCHEDULER_MONITOR'
+	AND rb.record LIKE N'%<SystemHealth>%'
+	AND rb.record_id > CASE WHEN CONVERT(varchar(23), si.sqlserver_start_time, 121) = @p2 THEN @p1 ELSE 0 END
+	ORDER BY rb.record_id
+`
+
+var fields = mssql.Field{
+	Name:        "cpu",
+	Type:        "group",
+	Description: "`cpu` contains the CPU utilization of the machine during a minute, recorded by the scheduler monitor.",
+	Fields: []mssql.Field{
+		{Name: "record_id", Type: "long", Description: "Id of the ring buffer record."},
+		{Name: "sql_process.pct", Type: "scaled_float", Format: "percent", Description: "CPU used by the SQL Server process."},
+		{Name: "system_idle.pct", Type: "scaled_float", Format: "percent", Description: "Idle CPU of the machine."},
+		{Name: "other_processes.pct", Type: "scaled_float", Format: "percent", Description: "CPU used by the other processes of the machine."},
+	},
+}
+
+// record is a scheduler monitor record of the ring buffer.
+type record struct {
+	id      int64
+	time    time.Time
+	started string
+
+	ProcessUtilization int64 `xml:"SchedulerMonitorEvent>SystemHealth>ProcessUtilization"`
+	SystemIdle         int64 `xml:"SchedulerMonitorEvent>SystemHealth>SystemIdle"`
+}
+
+// MetricSet reports the CPU utilization recorded every minute by the
+// scheduler monitor.
+type MetricSet struct {
+	*mssql.MetricSet
+	period time.Duration
+
+	// Id of the newest record published, and start time of the instance
+	// that recorded it, empty until a record was read.
+	lastRecordID int64
+	started      string
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, period: base.Module().Config().Period}, nil
+}
+
+// Fetch reports one event per minute recorded since the previous fetch, with
+// the time of the record. The first fetch only reports the records of the
+// last period: the minutes kept in the ring buffer were likely published
+// before the beat restarted. After a restart of the instance, all its records
+// are reported.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	records, err := m.queryRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range m.newRecords(records) {
+		event := mb.Event{
+			Timestamp:       rec.time,
+			MetricSetFields: rec.fields(),
+		}
+		if !r.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// newRecords returns the records not published yet, and remembers the newest
+// as published. The last record published is not kept across restarts of the
+// beat, so the records of the first fetch older than a period before the
+// newest one are skipped.
+func (m *MetricSet) newRecords(records []record) []record {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var since time.Time
+	newest := records[len(records)-1]
+	if m.started == "" {
+		since = newest.time.Add(-m.period)
+	}
+	if newest.started != m.started {
+		m.started = newest.started
+		m.lastRecordID = 0
+	}
+
+	var fresh []record
+	for _, rec := range records {
+		if rec.id > m.lastRecordID && rec.time.After(since) {
+			fresh = append(fresh, rec)
+			m.lastRecordID = rec.id
+		}
+	}
+	return fresh
+}
+
+func (rec record) fields() common.MapStr {
+	// The other processes use what is neither idle nor used by SQL Server.
+	other := 100 - rec.ProcessUtilization - rec.SystemIdle
+	if other < 0 {
+		other = 0
+	}
+	return common.MapStr{
+		"record_id":       rec.id,
+		"sql_process":     common.MapStr{"pct": float64(rec.ProcessUtilization) / 100},
+		"system_idle":     common.MapStr{"pct": float64(rec.SystemIdle) / 100},
+		"other_processes": common.MapStr{"pct": float64(other) / 100},
+	}
+}
+
+func (m *MetricSet) queryRecords(ctx context.Context) ([]record, error) {
+	var records []record
+	err := m.Query(ctx, query, func(rows mssql.Rows) error {
+		var rec record
+		var data string
+		if err := rows.Scan(&rec.id, &rec.time, &data, &rec.started); err != nil {
+			return err
+		}
+		if err := xml.Unmarshal([]byte(data), &rec); err != nil {
+			return fmt.Errorf("parsing ring buffer record %d: %v", rec.id, err)
+		}
+		records = append(records, rec)
+		return nil
+	}, m.lastRecordID, m.started)
+
+	return records, err
+}
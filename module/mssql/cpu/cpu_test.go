@@ -0,0 +1,42 @@
+// +build !integration
+
+package cpu
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRecords(t *testing.T) {
+	minute := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	records := func(started string, ids ...int64) []record {
+		var records []record
+		for _, id := range ids {
+			records = append(records, record{id: id, time: minute.Add(time.Duration(id) * time.Minute), started: started})
+		}
+		return records
+	}
+	started := "2024-03-01 08:00:00.000"
+
+	tests := []struct {
+		records []record
+		fresh   int
+	}{
+		// The first fetch only reports the records of the last period.
+		{records(started, 10, 11, 12), 1},
+		{records(started, 12, 13), 1},
+		{records(started, 12, 13), 0},
+		{nil, 0},
+		// Restarted, the record ids start from 0 again.
+		{records("2024-03-01 09:00:00.000", 1, 2), 2},
+	}
+	m := &MetricSet{period: 10 * time.Second}
+	for i, test := range tests {
+		if fresh := m.newRecords(test.records); len(fresh) != test.fresh {
+			t.Errorf("fetch %d: expected %d new records, got %d", i, test.fresh, len(fresh))
+		}
+	}
+	if m.lastRecordID != 2 {
+		t.Errorf("expected the last record id 2, got %d", m.lastRecordID)
+	}
+}
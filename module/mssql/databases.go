@@ -0,0 +1,96 @@
+package mssql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// databasesQuery lists the online user databases the login can access. The
+// databases of an availability group replica that cannot be read are not
+// accessible.
+const databasesQuery = `
+	SELECT name
+	FROM sys.databases
+	WHERE database_id > 4 AND state = 0 AND HAS_DBACCESS(name) = 1
+	ORDER BY name
+`
+
+// DatabaseFunc collects the data of a database.
+type DatabaseFunc func(ctx context.Context, database string) error
+
+// EachDatabase calls fn for every online user database the login can access,
+// in turn, with a context expiring after budget so that a large database does
+// not hold the others back. The databases that fail or overrun their budget
+// are logged and skipped. EachDatabase returns the first failure once all the
+// databases were collected, but not the overruns, which are expected on large
+// databases.
+func (m *MetricSet) EachDatabase(ctx context.Context, budget time.Duration, fn DatabaseFunc) error {
+	return eachDatabase(ctx, m, budget, fn, func(database string, err error) {
+		logp.Warn("Skipped database %s of %s in metricset %s: %v", database, m.HostData().SanitizedURI, m.Name(), err)
+	})
+}
+
+func eachDatabase(ctx context.Context, q Querier, budget time.Duration, fn DatabaseFunc, skipped func(string, error)) error {
+	var databases []string
+	err := q.Query(ctx, databasesQuery, func(rows Rows) error {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		databases = append(databases, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var first error
+	for _, database := range databases {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		dbCtx, cancel := context.WithTimeout(ctx, budget)
+		err := fn(dbCtx, database)
+		overrun := dbCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		cancel()
+
+		switch {
+		case err == nil:
+		case overrun:
+			skipped(database, fmt.Errorf("the collection overran its budget of %v", budget))
+		case ctx.Err() != nil:
+			return err
+		default:
+			skipped(database, err)
+			if first == nil {
+				first = fmt.Errorf("database %s: %v", database, err)
+			}
+		}
+	}
+	return first
+}
+
+// DatabaseQuery returns a batch running query in the database named by the
+// first argument of the batch, @p1. The other arguments are passed on to
+// query, which declares them in params, for example "@p2 int, @p3 float".
+func DatabaseQuery(query, params string) string {
+	var b strings.Builder
+	b.WriteString("DECLARE @sql nvarchar(max) = N'USE ' + QUOTENAME(@p1) + N';\n")
+	b.WriteString(strings.Replace(query, "'", "''", -1))
+	b.WriteString("';\nEXEC sys.sp_executesql @sql")
+	if params == "" {
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, ", N'%s'", params)
+	for _, param := range strings.Split(params, ",") {
+		name := strings.Fields(param)[0]
+		fmt.Fprintf(&b, ", %s = %s", name, name)
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+// +build !integration
+
+package mssql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEachDatabase(t *testing.T) {
+	q := NewReplayQuerier(&Recording{ResultSets: []ResultSet{
+		{Match: "HAS_DBACCESS(name) = 1", Rows: [][]interface{}{{"inventory"}, {"sales"}, {"staging"}}},
+	}})
+
+	var collected []string
+	skipped := map[string]error{}
+	err := eachDatabase(context.Background(), q, 10*time.Millisecond, func(ctx context.Context, database string) error {
+		switch database {
+		case "sales":
+			<-ctx.Done()
+			return ctx.Err()
+		case "staging":
+			return errors.New("failed")
+		}
+		collected = append(collected, database)
+		return nil
+	}, func(database string, err error) {
+		skipped[database] = err
+	})
+
+	if len(collected) != 1 || collected[0] != "inventory" {
+		t.Errorf("expected inventory to be collected, got %v", collected)
+	}
+	if len(skipped) != 2 || skipped["sales"] == nil || skipped["staging"] == nil {
+		t.Errorf("expected sales and staging to be skipped, got %v", skipped)
+	}
+	if err == nil || err.Error() != "database staging: failed" {
+		t.Errorf("expected the failure of staging but not the overrun of sales, got %v", err)
+	}
+}
+
+func TestDatabaseQuery(t *testing.T) {
+	query := DatabaseQuery("SELECT name FROM sys.indexes WHERE type_desc = 'HEAP' AND x > @p2", "@p2 int")
+	expected := `DECLARE @sql nvarchar(max) = N'USE ' + QUOTENAME(@p1) + N';
+SELECT name FROM sys.indexes WHERE type_desc = ''HEAP'' AND x > @p2';
+EXEC sys.sp_executesql @sql, N'@p2 int', @p2 = @p2`
+	if query != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, query)
+	}
+}
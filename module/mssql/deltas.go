@@ -0,0 +1,114 @@
+package mssql
+
+import (
+	"sort"
+)
+
+// Deltas keeps the cumulative values of the previous fetch of a metricset, to
+// report their increase over the interval between two fetches. Values are
+// identified by a key, such as a counter and its instance or a wait type.
+type Deltas struct {
+	last    map[string][]int64
+	current map[string][]int64
+}
+
+// NewDeltas creates the state of a metricset reporting interval values.
+func NewDeltas() *Deltas {
+	return &Deltas{current: map[string][]int64{}}
+}
+
+// Delta records the values of key for the current fetch and returns their
+// increase since the previous fetch. There is no increase on the first fetch,
+// for a new key, or when a value decreased because the statistics were
+// cleared or the server restarted.
+func (d *Deltas) Delta(key string, values ...int64) ([]int64, bool) {
+	d.current[key] = values
+
+	prev, found := d.last[key]
+	if !found || len(prev) != len(values) {
+		return nil, false
+	}
+	deltas := make([]int64, len(values))
+	for i, v := range values {
+		if v < prev[i] {
+			return nil, false
+		}
+		deltas[i] = v - prev[i]
+	}
+	return deltas, true
+}
+
+// Next ends a fetch. The values recorded become those of the previous fetch,
+// the keys not recorded are forgotten.
+func (d *Deltas) Next() {
+	d.last = d.current
+	d.current = map[string][]int64{}
+}
+
+// Baseline tells whether a previous fetch was recorded, the interval values
+// are missing until then.
+func (d *Deltas) Baseline() bool {
+	return d.last != nil
+}
+
+// Increase is the increase of the values of a key over the interval between
+// two fetches.
+type Increase struct {
+	Key    string
+	Deltas []int64
+}
+
+// Top selects the keys whose values increased the most over the interval
+// between two fetches, for the metricsets reporting only the busiest of many
+// statistics, such as spinlock types or latch classes.
+type Top struct {
+	deltas    *Deltas
+	n         int
+	active    int
+	by        int
+	increases map[string][]int64
+}
+
+// NewTop creates the state of a metricset reporting the n keys whose value at
+// index by increased the most, among those whose value at index active
+// increased.
+func NewTop(n, active, by int) *Top {
+	return &Top{deltas: NewDeltas(), n: n, active: active, by: by, increases: map[string][]int64{}}
+}
+
+// Add records the values of key for the current fetch.
+func (t *Top) Add(key string, values ...int64) {
+	deltas, ok := t.deltas.Delta(key, values...)
+	if ok && deltas[t.active] > 0 {
+		t.increases[key] = deltas
+	}
+}
+
+// Next ends a fetch and returns the increases of the selected keys, the
+// largest first and ties ordered by key. Nothing is selected on the first
+// fetch.
+func (t *Top) Next() []Increase {
+	top := make([]Increase, 0, len(t.increases))
+	for key, deltas := range t.increases {
+		top = append(top, Increase{Key: key, Deltas: deltas})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Deltas[t.by] != top[j].Deltas[t.by] {
+			return top[i].Deltas[t.by] > top[j].Deltas[t.by]
+		}
+		return top[i].Key < top[j].Key
+	})
+	if len(top) > t.n {
+		top = top[:t.n]
+	}
+
+	t.deltas.Next()
+	t.increases = map[string][]int64{}
+	return top
+}
+
+// Baseline tells whether a previous fetch was recorded, nothing is selected
+// until then.
+func (t *Top) Baseline() bool {
+	return t.deltas.Baseline()
+}
@@ -0,0 +1,67 @@
+// +build !integration
+
+package mssql
+
+import (
+	"testing"
+)
+
+func TestDeltas(t *testing.T) {
+	d := NewDeltas()
+	if _, ok := d.Delta("a", 10, 100); ok || d.Baseline() {
+		t.Fatal("expected no delta on the first fetch")
+	}
+	d.Next()
+
+	if delta, ok := d.Delta("a", 15, 130); !ok || delta[0] != 5 || delta[1] != 30 {
+		t.Errorf("expected a delta of [5 30], got %v", delta)
+	}
+	if _, ok := d.Delta("b", 1); ok {
+		t.Error("expected no delta for a new key")
+	}
+	d.Next()
+
+	// Cleared statistics.
+	if _, ok := d.Delta("a", 2, 140); ok {
+		t.Error("expected no delta after a value decreased")
+	}
+	d.Next()
+
+	if delta, ok := d.Delta("a", 4, 150); !ok || delta[0] != 2 || delta[1] != 10 {
+		t.Errorf("expected a delta of [2 10] after the statistics were cleared, got %v", delta)
+	}
+	if _, ok := d.Delta("b", 2); ok {
+		t.Error("expected a key missing from the previous fetch to be forgotten")
+	}
+}
+
+func TestTop(t *testing.T) {
+	top := NewTop(2, 0, 1)
+	top.Add("LOCK_HASH", 100, 10000)
+	top.Add("XDESMGR", 50, 20000)
+	if increases := top.Next(); len(increases) != 0 || !top.Baseline() {
+		t.Fatalf("expected nothing selected on the first fetch, got %v", increases)
+	}
+
+	top.Add("LOCK_HASH", 110, 15000)
+	top.Add("XDESMGR", 53, 29000)
+	top.Add("MUTEX", 0, 0)
+	top.Add("SOS_CACHESTORE", 1, 100)
+	increases := top.Next()
+	if len(increases) != 2 || increases[0].Key != "XDESMGR" || increases[1].Key != "LOCK_HASH" {
+		t.Fatalf("unexpected selection %v", increases)
+	}
+	if increases[0].Deltas[0] != 3 || increases[0].Deltas[1] != 9000 {
+		t.Errorf("expected the increases [3 9000], got %v", increases[0].Deltas)
+	}
+
+	// Keys that did not increase the active value are left out, ties are
+	// ordered by key.
+	top.Add("LOCK_HASH", 110, 16000)
+	top.Add("XDESMGR", 54, 29500)
+	top.Add("SOS_CACHESTORE", 3, 600)
+	increases = top.Next()
+	if len(increases) != 2 || increases[0].Key != "SOS_CACHESTORE" || increases[1].Key != "XDESMGR" {
+		t.Errorf("unexpected selection %v", increases)
+	}
+}
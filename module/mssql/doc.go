@@ -0,0 +1,4 @@
+/*
+Package mssql is a Metricbeat module for Microsoft SQL Server.
+*/
+package mssql
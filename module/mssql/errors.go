@@ -0,0 +1,73 @@
+package mssql
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	mssqldb "github.com/denisenkom/go-mssqldb"
+)
+
+// Error classes of a failed connection or query.
+const (
+	ErrorClassDNS     = "dns"
+	ErrorClassTCP     = "tcp"
+	ErrorClassTLS     = "tls"
+	ErrorClassLogin   = "login"
+	ErrorClassTimeout = "timeout"
+	ErrorClassQuery   = "query"
+	ErrorClassUnknown = "unknown"
+)
+
+// loginFailedErrors are the server errors raised when a login is rejected.
+var loginFailedErrors = map[int32]bool{
+	4060:  true, // Cannot open database requested by the login
+	18452: true, // Login from an untrusted domain
+	18456: true, // Login failed for user
+	18486: true, // Account locked out
+	18487: true, // Password expired
+	18488: true, // Password must be changed
+}
+
+// ErrorClass tells at which stage a connection or a query failed. Most
+// connection errors of the driver are plain strings, so they are recognized
+// by their message.
+func ErrorClass(ctx context.Context, err error) string {
+	if err == nil {
+		return ""
+	}
+	if ctx != nil && ctx.Err() == context.DeadlineExceeded {
+		return ErrorClassTimeout
+	}
+
+	switch e := err.(type) {
+	case *net.DNSError:
+		return ErrorClassDNS
+	case net.Error:
+		if e.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassTCP
+	case mssqldb.Error:
+		if loginFailedErrors[e.Number] {
+			return ErrorClassLogin
+		}
+		return ErrorClassQuery
+	}
+
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "no such host"), strings.Contains(msg, "Unable to get instances from Sql Server Browser"):
+		return ErrorClassDNS
+	case strings.Contains(msg, "i/o timeout"), strings.Contains(msg, "context deadline exceeded"):
+		return ErrorClassTimeout
+	case strings.HasPrefix(msg, "Unable to open tcp connection"):
+		return ErrorClassTCP
+	case strings.HasPrefix(msg, "TLS Handshake failed"), strings.Contains(msg, "Cannot read certificate"),
+		strings.HasPrefix(msg, "Server does not support encryption"):
+		return ErrorClassTLS
+	case strings.HasPrefix(msg, "Login error"), strings.HasPrefix(msg, "Login failed"):
+		return ErrorClassLogin
+	}
+	return ErrorClassUnknown
+}
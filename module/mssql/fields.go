@@ -0,0 +1,119 @@
+package mssql
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Field describes a field of the events. The fields.yml files of the module
+// and of the metricsets are generated from these definitions, so they always
+// declare what the code emits.
+type Field struct {
+	Name        string
+	Type        string
+	ObjectType  string // Type of the values of an object field.
+	Format      string
+	Description string
+	Fields      []Field // Fields of a group.
+}
+
+// moduleFields are the fields added by the module to the events of all
+// metricsets, in the mssql namespace.
+var moduleFields = Field{
+	Name:        "mssql",
+	Type:        "group",
+	Description: "`mssql` contains the metrics that were obtained from SQL Server.",
+	Fields: []Field{
+		{Name: "database.name", Type: "keyword", Description: "Name of the database the metrics belong to."},
+		instanceFields,
+	},
+}
+
+// moduleFieldsYAML returns the fields.yml of the module, declaring the
+// module fields.
+func moduleFieldsYAML() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("- key: mssql\n")
+	buf.WriteString("  title: \"MSSQL\"\n")
+	buf.WriteString("  description: >\n")
+	writeDescription(&buf, "    ", "Microsoft SQL Server metrics collected by mssqlbeat.")
+	buf.WriteString("  fields:\n")
+	writeField(&buf, "    ", moduleFields)
+	return buf.Bytes()
+}
+
+var (
+	fieldsMu        sync.RWMutex
+	metricSetFields = map[string]Field{}
+)
+
+// RegisterFields declares the group of fields of a metricset. It is called
+// from the init function of the metricset, next to its registration, so that
+// the fields.yml of every metricset is checked against its definitions.
+func RegisterFields(metricset string, group Field) {
+	fieldsMu.Lock()
+	defer fieldsMu.Unlock()
+
+	metricSetFields[metricset] = group
+}
+
+// MetricSetFields returns the group of fields declared by a metricset.
+func MetricSetFields(metricset string) (Field, bool) {
+	fieldsMu.RLock()
+	defer fieldsMu.RUnlock()
+
+	group, found := metricSetFields[metricset]
+	return group, found
+}
+
+// FieldsYAML returns the fields.yml of a metricset declaring the given group.
+func FieldsYAML(group Field) []byte {
+	var buf bytes.Buffer
+	writeField(&buf, "", group)
+	return buf.Bytes()
+}
+
+var plainName = regexp.MustCompile(`^[a-z0-9_.]+$`)
+
+func writeField(buf *bytes.Buffer, indent string, f Field) {
+	name := f.Name
+	if !plainName.MatchString(name) {
+		name = fmt.Sprintf("%q", name)
+	}
+	fmt.Fprintf(buf, "%s- name: %s\n", indent, name)
+	indent += "  "
+	fmt.Fprintf(buf, "%stype: %s\n", indent, f.Type)
+	if f.ObjectType != "" {
+		fmt.Fprintf(buf, "%sobject_type: %s\n", indent, f.ObjectType)
+	}
+	if f.Format != "" {
+		fmt.Fprintf(buf, "%sformat: %s\n", indent, f.Format)
+	}
+	fmt.Fprintf(buf, "%sdescription: >\n", indent)
+	writeDescription(buf, indent+"  ", f.Description)
+	if len(f.Fields) > 0 {
+		fmt.Fprintf(buf, "%sfields:\n", indent)
+		for _, child := range f.Fields {
+			writeField(buf, indent+"  ", child)
+		}
+	}
+}
+
+// writeDescription writes a folded description wrapped at 80 columns.
+func writeDescription(buf *bytes.Buffer, indent, description string) {
+	line := indent
+	for _, word := range strings.Fields(description) {
+		if len(line) > len(indent) && len(line)+1+len(word) > 80 {
+			buf.WriteString(line + "\n")
+			line = indent
+		}
+		if len(line) > len(indent) {
+			line += " "
+		}
+		line += word
+	}
+	buf.WriteString(line + "\n")
+}
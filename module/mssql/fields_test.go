@@ -0,0 +1,40 @@
+// +build !integration
+
+package mssql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql/mtest"
+)
+
+func TestFieldsYAML(t *testing.T) {
+	mtest.CheckFieldsYAML(t, "", moduleFieldsYAML())
+}
+
+func TestInstanceFieldsDeclared(t *testing.T) {
+	p := serverProperties{
+		serverName:    "SQL01\\INST1",
+		version:       "14.0.3045.24",
+		level:         "RTM",
+		edition:       "Developer Edition (64-bit)",
+		machine:       "SQL01",
+		instance:      sql.NullString{String: "INST1", Valid: true},
+		engineEdition: sql.NullInt64{Int64: 3, Valid: true},
+		clustered:     sql.NullInt64{Int64: 0, Valid: true},
+		hadr:          sql.NullInt64{Int64: 1, Valid: true},
+	}
+	info := sysInfo{cpus: 8, memoryKB: 16777216, startTime: time.Now()}
+
+	mtest.CheckEventFields(t, "", mb.Event{
+		ModuleFields: common.MapStr{
+			"database": common.MapStr{"name": "master"},
+			"instance": instanceEventFields(p, &info),
+		},
+	})
+}
@@ -0,0 +1,248 @@
+package identity
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "identity", New,
+		mb.WithHostParser(mssql.ParseURL),
+	)
+	mssql.RegisterFields("identity", fields)
+	mssql.RequirePermissions("identity", mssql.ViewAnyDefinition, mssql.DatabaseAccess)
+}
+
+// Identity columns of the user tables and sequences, with their last value.
+// The values are read as decimal(38, 0), which holds those of every type.
+// The bounds of an identity column are those of its type.
+var identityQuery = mssql.DatabaseQuery(`
+	SELECT
+		'column', SCHEMA_NAME(o.schema_id), o.name, c.name, TYPE_NAME(c.system_type_id), c.precision,
+		CAST(c.seed_value AS decimal(38, 0)), CAST(c.increment_value AS decimal(38, 0)),
+		NULL, NULL, CAST(c.last_value AS decimal(38, 0)), CAST(0 AS bit)
+	FROM sys.identity_columns AS c
+	JOIN sys.objects AS o ON o.object_id = c.object_id
+	WHERE o.type = 'U' AND o.is_ms_shipped = 0
+	UNION ALL
+	SELECT
+		'sequence', SCHEMA_NAME(s.schema_id), s.name, NULL, TYPE_NAME(s.system_type_id), s.precision,
+		CAST(s.start_value AS decimal(38, 0)), CAST(s.increment AS decimal(38, 0)),
+		CAST(s.minimum_value AS decimal(38, 0)), CAST(s.maximum_value AS decimal(38, 0)),
+		CAST(s.current_value AS decimal(38, 0)), s.is_cycling
+	FROM sys.sequences AS s
+`, "")
+
+var fields = mssql.Field{
+	Name:        "identity",
+	Type:        "group",
+	Description: "`identity` contains the values left to an identity column or a sequence.",
+	Fields: []mssql.Field{
+		{Name: "kind", Type: "keyword", Description: "Either column, for an identity column, or sequence."},
+		{Name: "schema", Type: "keyword", Description: "Schema of the table or sequence."},
+		{Name: "name", Type: "keyword", Description: "Name of the table or sequence."},
+		{Name: "column", Type: "keyword", Description: "Name of the identity column."},
+		{Name: "type", Type: "keyword", Description: "Data type of the values, such as int or bigint."},
+		{Name: "increment", Type: "long", Description: "Increment between two values."},
+		{Name: "last_value", Type: "long", Description: "Last value generated, not set when it does not fit a long."},
+		{Name: "remaining.count", Type: "long", Description: "Number of values that can still be generated before reaching the bound of the range, not set when it does not fit a long."},
+		{Name: "used.pct", Type: "scaled_float", Format: "percent", Description: "Part of the range from the first value to the bound consumed. Inserts fail when it reaches 100%, unless the sequence cycles."},
+		{Name: "cycling", Type: "boolean", Description: "Whether the sequence starts over when it reaches its bound."},
+	},
+}
+
+type identity struct {
+	kind, schema, name, typ string
+	column                  sql.NullString
+	precision               int64
+	start, increment        string
+	min, max, last          sql.NullString
+	cycling                 bool
+}
+
+// usage is the consumption of the range of an identity column or sequence.
+type usage struct {
+	used      float64
+	remaining *big.Int
+}
+
+// MetricSet reports the identity columns and sequences of every database
+// close to the bound of their range.
+type MetricSet struct {
+	*mssql.MetricSet
+	budget    time.Duration
+	threshold float64
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Budget    time.Duration `config:"identity.database_timeout" validate:"positive"`
+		Threshold float64       `config:"identity.threshold" validate:"min=0,max=100"`
+	}{time.Minute, 50}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, budget: config.Budget, threshold: config.Threshold / 100}, nil
+}
+
+// Fetch reports, for every user database, one event per identity column or
+// sequence that consumed at least identity.threshold percent of its range. A
+// database taking longer than identity.database_timeout is skipped.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	return m.EachDatabase(ctx, m.budget, func(ctx context.Context, database string) error {
+		// Events are only sent once the database was collected, a database
+		// skipped after its budget is not partially reported.
+		var events []common.MapStr
+		err := m.Query(ctx, identityQuery, func(rows mssql.Rows) error {
+			var id identity
+			if err := rows.Scan(&id.kind, &id.schema, &id.name, &id.column, &id.typ, &id.precision,
+				&id.start, &id.increment, &id.min, &id.max, &id.last, &id.cycling); err != nil {
+				return err
+			}
+			u, ok := id.usage()
+			if ok && u.used >= m.threshold {
+				events = append(events, id.fields(u))
+			}
+			return nil
+		}, database)
+		if err != nil {
+			return err
+		}
+
+		for _, fields := range events {
+			event := mb.Event{
+				ModuleFields: common.MapStr{
+					"database": common.MapStr{
+						"name": database,
+					},
+				},
+				MetricSetFields: fields,
+			}
+			if !r.Event(event) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (id identity) fields(u usage) common.MapStr {
+	fields := common.MapStr{
+		"kind":   id.kind,
+		"schema": id.schema,
+		"name":   id.name,
+		"type":   id.typ,
+		"used":   common.MapStr{"pct": u.used},
+	}
+	if id.column.Valid {
+		fields.Put("column", id.column.String)
+	}
+	if id.kind == "sequence" {
+		fields.Put("cycling", id.cycling)
+	}
+	if increment, ok := parseInt(id.increment); ok && increment.IsInt64() {
+		fields.Put("increment", increment.Int64())
+	}
+	if last, ok := parseInt(id.last.String); ok && last.IsInt64() {
+		fields.Put("last_value", last.Int64())
+	}
+	if u.remaining.IsInt64() {
+		fields.Put("remaining.count", u.remaining.Int64())
+	}
+	return fields
+}
+
+// usage returns the part of the range from the first value to the bound that
+// was consumed, and the number of values left. The bound is the maximum for a
+// positive increment and the minimum for a negative one. It returns false
+// when no value was generated yet or the values cannot be parsed.
+func (id identity) usage() (usage, bool) {
+	start, ok1 := parseInt(id.start)
+	increment, ok2 := parseInt(id.increment)
+	last, ok3 := parseInt(id.last.String)
+	if !ok1 || !ok2 || !ok3 || !id.last.Valid || increment.Sign() == 0 {
+		return usage{}, false
+	}
+
+	min, max, ok := typeRange(id.typ, id.precision)
+	if id.min.Valid && id.max.Valid {
+		min, ok1 = parseInt(id.min.String)
+		max, ok2 = parseInt(id.max.String)
+		ok = ok1 && ok2
+	}
+	if !ok {
+		return usage{}, false
+	}
+
+	// Distances from the first and last values to the bound, in the
+	// direction of the increment.
+	var total, left big.Int
+	if increment.Sign() > 0 {
+		total.Sub(max, start)
+		left.Sub(max, last)
+	} else {
+		total.Sub(start, min)
+		left.Sub(last, min)
+		increment.Neg(increment)
+	}
+	if total.Sign() <= 0 {
+		return usage{used: 1, remaining: new(big.Int)}, true
+	}
+
+	if left.Sign() < 0 {
+		left.SetInt64(0)
+	}
+	var consumed big.Int
+	consumed.Sub(&total, &left)
+	if consumed.Sign() < 0 {
+		// Reseeded before the first value.
+		consumed.SetInt64(0)
+	}
+	used, _ := new(big.Rat).SetFrac(&consumed, &total).Float64()
+	return usage{used: used, remaining: left.Quo(&left, increment)}, true
+}
+
+// typeRange returns the minimum and maximum values of an integer type, or of
+// a decimal type with precision digits and no decimals.
+func typeRange(typ string, precision int64) (min, max *big.Int, ok bool) {
+	switch typ {
+	case "tinyint":
+		return big.NewInt(0), big.NewInt(255), true
+	case "smallint":
+		return big.NewInt(-1 << 15), big.NewInt(1<<15 - 1), true
+	case "int":
+		return big.NewInt(-1 << 31), big.NewInt(1<<31 - 1), true
+	case "bigint":
+		return big.NewInt(-1 << 63), big.NewInt(1<<63 - 1), true
+	case "decimal", "numeric":
+		if precision < 1 || precision > 38 {
+			return nil, nil, false
+		}
+		max := new(big.Int).Exp(big.NewInt(10), big.NewInt(precision), nil)
+		max.Sub(max, big.NewInt(1))
+		return new(big.Int).Neg(max), max, true
+	}
+	return nil, nil, false
+}
+
+func parseInt(s string) (*big.Int, bool) {
+	return new(big.Int).SetString(s, 10)
+}
@@ -0,0 +1,77 @@
+// +build !integration
+
+package identity
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+)
+
+func TestUsage(t *testing.T) {
+	last := func(v string) sql.NullString { return sql.NullString{String: v, Valid: true} }
+	tests := []struct {
+		name      string
+		id        identity
+		used      float64
+		remaining int64
+		ok        bool
+	}{
+		{
+			"int close to its maximum",
+			identity{typ: "int", start: "1", increment: "1", last: last("2147483000")},
+			0.9999996987, 647, true,
+		},
+		{
+			"int seeded at its minimum",
+			identity{typ: "int", start: "-2147483648", increment: "1", last: last("-1")},
+			0.5, 2147483648, true,
+		},
+		{
+			"smallint with a negative increment",
+			identity{typ: "smallint", start: "0", increment: "-2", last: last("-16384")},
+			0.5, 8192, true,
+		},
+		{
+			"tinyint reseeded below its seed",
+			identity{typ: "tinyint", start: "10", increment: "1", last: last("3")},
+			0, 252, true,
+		},
+		{
+			"sequence with its own bounds",
+			identity{typ: "bigint", start: "1", increment: "1", min: last("1"), max: last("1001"), last: last("751")},
+			0.75, 250, true,
+		},
+		{
+			"decimal with 38 digits",
+			identity{typ: "decimal", precision: 38, start: "1", increment: "1", last: last("1000")},
+			0, 0, true,
+		},
+		{
+			"no value generated",
+			identity{typ: "int", start: "1", increment: "1"},
+			0, 0, false,
+		},
+		{
+			"unsupported type",
+			identity{typ: "float", start: "1", increment: "1", last: last("10")},
+			0, 0, false,
+		},
+	}
+	for _, test := range tests {
+		u, ok := test.id.usage()
+		if ok != test.ok {
+			t.Errorf("%s: expected ok %v, got %v", test.name, test.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if math.Abs(u.used-test.used) > 1e-9 {
+			t.Errorf("%s: expected %v of the range used, got %v", test.name, test.used, u.used)
+		}
+		if test.remaining != 0 && (!u.remaining.IsInt64() || u.remaining.Int64() != test.remaining) {
+			t.Errorf("%s: expected %d values left, got %v", test.name, test.remaining, u.remaining)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package indexes
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "indexes", New,
+		mb.WithHostParser(mssql.ParseURL),
+	)
+	mssql.RegisterFields("indexes", fields)
+	mssql.RequirePermissions("indexes", mssql.ViewServerState, mssql.ViewAnyDefinition, mssql.DatabaseAccess)
+}
+
+// Nonclustered indexes of the user tables not read since the instance
+// started, with the cost of maintaining them. Unique indexes are left out as
+// they enforce a constraint. Times are converted from the server local time
+// to UTC.
+var unusedQuery = mssql.DatabaseQuery(`
+	SELECT
+		SCHEMA_NAME(o.schema_id), o.name, i.name, i.type_desc,
+		ISNULL(u.user_updates, 0),
+		DATEADD(MINUTE, DATEDIFF(MINUTE, GETDATE(), GETUTCDATE()), u.last_user_update),
+		ISNULL(p.pages, 0)
+	FROM sys.indexes AS i
+	JOIN sys.objects AS o ON o.object_id = i.object_id
+	LEFT JOIN sys.dm_db_index_usage_stats AS u
+		ON u.database_id = DB_ID() AND u.object_id = i.object_id AND u.index_id = i.index_id
+	OUTER APPLY (
+		SELECT SUM(ps.used_page_count) AS pages
+		FROM sys.dm_db_partition_stats AS ps
+		WHERE ps.object_id = i.object_id AND ps.index_id = i.index_id
+	) AS p
+	WHERE o.type = 'U' AND o.is_ms_shipped = 0
+		AND i.type = 2 AND i.is_unique = 0 AND i.is_disabled = 0 AND i.is_hypothetical = 0
+		AND ISNULL(u.user_seeks + u.user_scans + u.user_lookups, 0) = 0
+	ORDER BY p.pages DESC
+`, "")
+
+// The @p2 missing indexes of the database named by @p1 that would most reduce
+// the cost of the queries.
+const missingQuery = `
+	SELECT TOP (@p2)
+		OBJECT_SCHEMA_NAME(d.object_id, d.database_id), OBJECT_NAME(d.object_id, d.database_id),
+		d.equality_columns, d.inequality_columns, d.included_columns,
+		s.user_seeks, s.user_scans,
+		DATEADD(MINUTE, DATEDIFF(MINUTE, GETDATE(), GETUTCDATE()), s.last_user_seek),
+		s.avg_total_user_cost, s.avg_user_impact
+	FROM sys.dm_db_missing_index_details AS d
+	JOIN sys.dm_db_missing_index_groups AS g ON g.index_handle = d.index_handle
+	JOIN sys.dm_db_missing_index_group_stats AS s ON s.group_handle = g.index_group_handle
+	WHERE d.database_id = DB_ID(@p1)
+	ORDER BY s.avg_total_user_cost * s.avg_user_impact * (s.user_seeks + s.user_scans) DESC
+`
+
+// Fragmentation of the partitions of the rowstore indexes with at least @p2
+// pages. The partitions are filtered before calling
+// sys.dm_db_index_physical_stats so that the small ones are not read.
+var fragmentationQuery = mssql.DatabaseQuery(`
+	SELECT
+		SCHEMA_NAME(o.schema_id), o.name, i.name, i.type_desc, ps.partition_number,
+		f.avg_fragmentation_in_percent, f.fragment_count, f.page_count
+	FROM sys.dm_db_partition_stats AS ps
+	JOIN sys.indexes AS i ON i.object_id = ps.object_id AND i.index_id = ps.index_id
+	JOIN sys.objects AS o ON o.object_id = ps.object_id
+	CROSS APPLY sys.dm_db_index_physical_stats(DB_ID(), ps.object_id, ps.index_id, ps.partition_number, 'LIMITED') AS f
+	WHERE o.is_ms_shipped = 0 AND i.type IN (1, 2) AND ps.in_row_used_page_count >= @p2
+		AND f.alloc_unit_type_desc = 'IN_ROW_DATA'
+	ORDER BY f.page_count DESC
+`, "@p2 bigint")
+
+var fields = mssql.Field{
+	Name:        "indexes",
+	Type:        "group",
+	Description: "`indexes` contains an unused index, a missing index suggested by the optimizer, or the fragmentation of an index partition.",
+	Fields: []mssql.Field{
+		{Name: "schema", Type: "keyword", Description: "Schema of the table."},
+		{Name: "table", Type: "keyword", Description: "Name of the table."},
+		{Name: "name", Type: "keyword", Description: "Name of the index, not set for missing indexes."},
+		{Name: "type", Type: "keyword", Description: "Type of the index, CLUSTERED or NONCLUSTERED."},
+		{Name: "size.kb", Type: "long", Description: "Size of the index, or of the index partition for the fragmentation."},
+		{Name: "unused.updates.count", Type: "long", Description: "Number of updates of the unused index since the instance started, the cost of keeping it."},
+		{Name: "unused.last_update", Type: "date", Description: "Time the unused index was last updated."},
+		{Name: "missing.equality_columns", Type: "keyword", Description: "Columns of the equality predicates the missing index would serve."},
+		{Name: "missing.inequality_columns", Type: "keyword", Description: "Columns of the other predicates the missing index would serve."},
+		{Name: "missing.included_columns", Type: "keyword", Description: "Columns the queries need, to be included in the missing index."},
+		{Name: "missing.seeks.count", Type: "long", Description: "Number of seeks the missing index would have served."},
+		{Name: "missing.scans.count", Type: "long", Description: "Number of scans the missing index would have served."},
+		{Name: "missing.last_seek", Type: "date", Description: "Time of the last seek the missing index would have served."},
+		{Name: "missing.avg_cost", Type: "float", Description: "Average estimated cost of the queries the missing index would serve."},
+		{Name: "missing.avg_impact.pct", Type: "scaled_float", Format: "percent", Description: "Average estimated reduction of the cost of the queries with the missing index."},
+		{Name: "missing.improvement", Type: "float", Description: "Estimated reduction of the total cost of the queries with the missing index, the average cost times the average impact times the seeks and scans. Used to rank the missing indexes."},
+		{Name: "fragmentation.partition", Type: "integer", Description: "Number of the partition of the index."},
+		{Name: "fragmentation.pct", Type: "scaled_float", Format: "percent", Description: "Logical fragmentation of the leaf level of the index partition, the pages out of order."},
+		{Name: "fragmentation.fragments.count", Type: "long", Description: "Number of fragments of the leaf level, runs of physically consecutive pages."},
+	},
+}
+
+// pageKB is the size of a page.
+const pageKB = 8
+
+type unusedIndex struct {
+	schema, table, name, kind string
+	updates, pages            int64
+	lastUpdate                sql.NullTime
+}
+
+type missingIndex struct {
+	schema, table                  sql.NullString
+	equality, inequality, included sql.NullString
+	seeks, scans                   int64
+	lastSeek                       sql.NullTime
+	cost, impact                   float64
+}
+
+type fragmentation struct {
+	schema, table, name, kind string
+	partition                 int64
+	pct                       float64
+	fragments                 sql.NullInt64
+	pages                     int64
+}
+
+// MetricSet reports the unused indexes, the missing indexes and the
+// fragmentation of the indexes of every database. It reads every index and is
+// meant to run with a long period.
+type MetricSet struct {
+	*mssql.MetricSet
+	budget   time.Duration
+	missing  int
+	minPages int64
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Budget   time.Duration `config:"indexes.database_timeout" validate:"positive"`
+		Missing  int           `config:"indexes.missing.top" validate:"min=0"`
+		MinPages int64         `config:"indexes.fragmentation.min_pages" validate:"min=0"`
+	}{time.Minute, 20, 1000}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, budget: config.Budget, missing: config.Missing, minPages: config.MinPages}, nil
+}
+
+// Fetch reports, for every user database, one event per unused index, per
+// missing index among the indexes.missing.top with the largest estimated
+// improvement, and per partition of the indexes of at least
+// indexes.fragmentation.min_pages pages with its fragmentation. A database
+// taking longer than indexes.database_timeout is skipped.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	return m.EachDatabase(ctx, m.budget, func(ctx context.Context, database string) error {
+		// Events are only sent once the database was collected, a database
+		// skipped after its budget is not partially reported.
+		var events []common.MapStr
+		err := m.Query(ctx, unusedQuery, func(rows mssql.Rows) error {
+			var u unusedIndex
+			if err := rows.Scan(&u.schema, &u.table, &u.name, &u.kind, &u.updates, &u.lastUpdate, &u.pages); err != nil {
+				return err
+			}
+			events = append(events, u.fields())
+			return nil
+		}, database)
+		if err != nil {
+			return err
+		}
+
+		if m.missing > 0 {
+			err = m.Query(ctx, missingQuery, func(rows mssql.Rows) error {
+				var mi missingIndex
+				if err := rows.Scan(&mi.schema, &mi.table, &mi.equality, &mi.inequality, &mi.included,
+					&mi.seeks, &mi.scans, &mi.lastSeek, &mi.cost, &mi.impact); err != nil {
+					return err
+				}
+				events = append(events, mi.fields())
+				return nil
+			}, database, m.missing)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = m.Query(ctx, fragmentationQuery, func(rows mssql.Rows) error {
+			var f fragmentation
+			if err := rows.Scan(&f.schema, &f.table, &f.name, &f.kind, &f.partition, &f.pct, &f.fragments, &f.pages); err != nil {
+				return err
+			}
+			events = append(events, f.fields())
+			return nil
+		}, database, m.minPages)
+		if err != nil {
+			return err
+		}
+
+		for _, fields := range events {
+			event := mb.Event{
+				ModuleFields: common.MapStr{
+					"database": common.MapStr{
+						"name": database,
+					},
+				},
+				MetricSetFields: fields,
+			}
+			if !r.Event(event) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (u unusedIndex) fields() common.MapStr {
+	fields := common.MapStr{
+		"schema": u.schema,
+		"table":  u.table,
+		"name":   u.name,
+		"type":   u.kind,
+		"size":   common.MapStr{"kb": u.pages * pageKB},
+		"unused": common.MapStr{
+			"updates": common.MapStr{"count": u.updates},
+		},
+	}
+	if u.lastUpdate.Valid {
+		fields.Put("unused.last_update", common.Time(u.lastUpdate.Time))
+	}
+	return fields
+}
+
+func (mi missingIndex) fields() common.MapStr {
+	missing := common.MapStr{
+		"seeks":       common.MapStr{"count": mi.seeks},
+		"scans":       common.MapStr{"count": mi.scans},
+		"avg_cost":    mi.cost,
+		"avg_impact":  common.MapStr{"pct": mi.impact / 100},
+		"improvement": mi.improvement(),
+	}
+	for name, columns := range map[string]sql.NullString{
+		"equality_columns":   mi.equality,
+		"inequality_columns": mi.inequality,
+		"included_columns":   mi.included,
+	} {
+		if columns.Valid {
+			missing.Put(name, columns.String)
+		}
+	}
+	if mi.lastSeek.Valid {
+		missing.Put("last_seek", common.Time(mi.lastSeek.Time))
+	}
+
+	fields := common.MapStr{"missing": missing}
+	if mi.schema.Valid {
+		fields.Put("schema", mi.schema.String)
+	}
+	if mi.table.Valid {
+		fields.Put("table", mi.table.String)
+	}
+	return fields
+}
+
+// improvement estimates the reduction of the total cost of the queries with
+// the missing index.
+func (mi missingIndex) improvement() float64 {
+	return mi.cost * mi.impact / 100 * float64(mi.seeks+mi.scans)
+}
+
+func (f fragmentation) fields() common.MapStr {
+	fragmentation := common.MapStr{
+		"partition": f.partition,
+		"pct":       f.pct / 100,
+	}
+	if f.fragments.Valid {
+		fragmentation.Put("fragments.count", f.fragments.Int64)
+	}
+	return common.MapStr{
+		"schema":        f.schema,
+		"table":         f.table,
+		"name":          f.name,
+		"type":          f.kind,
+		"size":          common.MapStr{"kb": f.pages * pageKB},
+		"fragmentation": fragmentation,
+	}
+}
@@ -0,0 +1,14 @@
+// +build !integration
+
+package indexes
+
+import (
+	"testing"
+)
+
+func TestImprovement(t *testing.T) {
+	mi := missingIndex{seeks: 1022, scans: 118, cost: 4.5, impact: 60}
+	if improvement := mi.improvement(); improvement != 3078 {
+		t.Errorf("expected an improvement of 3078, got %v", improvement)
+	}
+}
@@ -0,0 +1,270 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// DefaultInstanceRefresh is the default interval at which the instance
+// metadata is queried again.
+const DefaultInstanceRefresh = 5 * time.Minute
+
+const propertiesQuery = `
+	SELECT
+		@@SERVERNAME,
+		CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)),
+		CAST(SERVERPROPERTY('ProductLevel') AS nvarchar(128)),
+		CAST(SERVERPROPERTY('Edition') AS nvarchar(128)),
+		CAST(SERVERPROPERTY('EngineEdition') AS int),
+		CAST(SERVERPROPERTY('IsClustered') AS int),
+		CAST(SERVERPROPERTY('IsHadrEnabled') AS int),
+		CAST(SERVERPROPERTY('MachineName') AS nvarchar(128)),
+		CAST(SERVERPROPERTY('InstanceName') AS nvarchar(128))
+`
+
+// sys.dm_os_sys_info requires VIEW SERVER STATE. The start time is converted
+// from the server local time to UTC.
+const sysInfoQuery = `
+	SELECT
+		cpu_count,
+		physical_memory_kb,
+		DATEADD(MINUTE, DATEDIFF(MINUTE, GETDATE(), GETUTCDATE()), sqlserver_start_time)
+	FROM sys.dm_os_sys_info
+`
+
+var instanceFields = Field{
+	Name:        "instance",
+	Type:        "group",
+	Description: "Metadata of the SQL Server instance, added to all events.",
+	Fields: []Field{
+		{Name: "server_name", Type: "keyword", Description: "Name of the server, as returned by @@SERVERNAME."},
+		{Name: "name", Type: "keyword", Description: "Name of the instance, not set for the default instance."},
+		{Name: "machine_name", Type: "keyword", Description: "Name of the machine running the instance. On a failover cluster instance it changes when the instance fails over."},
+		{Name: "version", Type: "keyword", Description: "Product version of the instance."},
+		{Name: "level", Type: "keyword", Description: "Product level of the instance, for example RTM or SP1."},
+		{Name: "edition", Type: "keyword", Description: "Product edition of the instance."},
+		{Name: "engine_edition", Type: "integer", Description: "Database engine edition of the instance."},
+		{Name: "clustered", Type: "boolean", Description: "Whether the instance is a failover cluster instance."},
+		{Name: "hadr_enabled", Type: "boolean", Description: "Whether Always On availability groups are enabled."},
+		{Name: "cpu.count", Type: "integer", Description: "Number of logical CPUs of the machine."},
+		{Name: "memory.physical.bytes", Type: "long", Format: "bytes", Description: "Physical memory of the machine."},
+		{Name: "start_time", Type: "date", Description: "Time the instance was started."},
+	},
+}
+
+// Instance caches the metadata of the SQL Server instance behind a host. It
+// is shared by the metricsets of the host and added to all their events.
+type Instance struct {
+	uri     string
+	refresh time.Duration
+
+	mu        sync.Mutex
+	fields    common.MapStr
+	updated   time.Time
+	refs      int
+	reconnect bool
+}
+
+var (
+	instancesLock sync.Mutex
+	instances     = map[string]*Instance{}
+)
+
+// acquireInstance returns the metadata cache of the host with the given URI,
+// creating it on first use. Caches are released with release.
+func acquireInstance(uri string, refresh time.Duration) *Instance {
+	instancesLock.Lock()
+	defer instancesLock.Unlock()
+
+	i, found := instances[uri]
+	if !found {
+		i = &Instance{uri: uri, refresh: refresh}
+		instances[uri] = i
+	}
+	if refresh < i.refresh {
+		i.refresh = refresh
+	}
+	i.refs++
+	return i
+}
+
+func (i *Instance) release() {
+	instancesLock.Lock()
+	defer instancesLock.Unlock()
+
+	if i.refs--; i.refs == 0 {
+		delete(instances, i.uri)
+	}
+}
+
+// Fields returns the cached metadata, querying it first when it is older than
+// the refresh interval or a fetch failed since the last query. Failing to
+// query it does not fail the fetch, the previous metadata is kept.
+func (i *Instance) Fields(ctx context.Context, m *MetricSet) common.MapStr {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.fields != nil && !i.reconnect && time.Since(i.updated) < i.refresh {
+		return i.fields
+	}
+
+	fields, err := queryInstance(ctx, m)
+	if err != nil {
+		logp.Debug("mssql", "Failed to query instance metadata of %s: %v", m.HostData().SanitizedURI, err)
+		return i.fields
+	}
+
+	if i.fields != nil {
+		prev, _ := i.fields.GetValue("machine_name")
+		if cur, _ := fields.GetValue("machine_name"); prev != cur {
+			logp.Info("Instance %s is now served by %v, was %v", m.HostData().SanitizedURI, cur, prev)
+		}
+	}
+
+	i.fields = fields
+	i.updated = time.Now()
+	i.reconnect = false
+	return fields
+}
+
+// cached returns the metadata queried last, without refreshing it, nil until
+// it was queried once.
+func (i *Instance) cached() common.MapStr {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.fields
+}
+
+// ServerName returns the cached @@SERVERNAME, empty until the metadata was
+// queried once.
+func (i *Instance) ServerName() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	name, _ := i.fields["server_name"].(string)
+	return name
+}
+
+// Version returns the cached product version of the instance, zero until the
+// metadata was queried once.
+func (i *Instance) Version() Version {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	version, _ := i.fields["version"].(string)
+	return ParseVersion(version)
+}
+
+// Invalidate marks the metadata for a refresh. It is called when a fetch
+// fails, as the next connection may reach a different server after a
+// failover.
+func (i *Instance) Invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.reconnect = true
+}
+
+// Version is a SQL Server product version, such as 13.0.5026.0 for SQL Server
+// 2016 SP2.
+type Version struct {
+	Major, Minor, Build int
+}
+
+// ParseVersion parses the major, minor and build numbers of a product
+// version. The parts that cannot be parsed are zero.
+func ParseVersion(s string) Version {
+	var v Version
+	parts := strings.SplitN(s, ".", 4)
+	for i, p := range []*int{&v.Major, &v.Minor, &v.Build} {
+		if i < len(parts) {
+			*p, _ = strconv.Atoi(parts[i])
+		}
+	}
+	return v
+}
+
+// AtLeast tells whether the version is the given version or a later one.
+func (v Version) AtLeast(major, minor, build int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Build >= build
+}
+
+// serverProperties are the values of @@SERVERNAME and SERVERPROPERTY.
+type serverProperties struct {
+	serverName, version, level, edition, machine string
+	instance                                     sql.NullString
+	engineEdition, clustered, hadr               sql.NullInt64
+}
+
+// sysInfo is the host information of sys.dm_os_sys_info.
+type sysInfo struct {
+	cpus, memoryKB int64
+	startTime      time.Time
+}
+
+func queryInstance(ctx context.Context, m *MetricSet) (common.MapStr, error) {
+	var p serverProperties
+	err := m.Query(ctx, propertiesQuery, func(rows Rows) error {
+		return rows.Scan(&p.serverName, &p.version, &p.level, &p.edition, &p.engineEdition, &p.clustered, &p.hadr, &p.machine, &p.instance)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var info sysInfo
+	err = m.Query(ctx, sysInfoQuery, func(rows Rows) error {
+		return rows.Scan(&info.cpus, &info.memoryKB, &info.startTime)
+	})
+	if err != nil {
+		// Keep the server properties, the login may lack VIEW SERVER STATE.
+		logp.Debug("mssql", "Failed to query system information of %s: %v", m.HostData().SanitizedURI, err)
+		return instanceEventFields(p, nil), nil
+	}
+
+	return instanceEventFields(p, &info), nil
+}
+
+// instanceEventFields returns the instance metadata added to the events. The
+// system information is nil when it could not be queried.
+func instanceEventFields(p serverProperties, info *sysInfo) common.MapStr {
+	fields := common.MapStr{
+		"server_name":  p.serverName,
+		"version":      p.version,
+		"level":        p.level,
+		"edition":      p.edition,
+		"machine_name": p.machine,
+	}
+	if p.instance.Valid {
+		fields.Put("name", p.instance.String)
+	}
+	if p.engineEdition.Valid {
+		fields.Put("engine_edition", p.engineEdition.Int64)
+	}
+	if p.clustered.Valid {
+		fields.Put("clustered", p.clustered.Int64 == 1)
+	}
+	if p.hadr.Valid {
+		fields.Put("hadr_enabled", p.hadr.Int64 == 1)
+	}
+
+	if info != nil {
+		fields.Put("cpu.count", info.cpus)
+		fields.Put("memory.physical.bytes", info.memoryKB*1024)
+		fields.Put("start_time", common.Time(info.startTime))
+	}
+	return fields
+}
@@ -0,0 +1,26 @@
+// +build !integration
+
+package mssql
+
+import (
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		version             string
+		major, minor, build int
+		atLeast             bool
+	}{
+		{"13.0.5026.0", 13, 0, 5026, true},
+		{"13.0.4001.0", 13, 0, 5026, false},
+		{"14.0.1000.169", 13, 0, 5026, true},
+		{"12.0.6444.4", 13, 0, 0, false},
+		{"", 11, 0, 0, false},
+	}
+	for _, test := range tests {
+		if atLeast := ParseVersion(test.version).AtLeast(test.major, test.minor, test.build); atLeast != test.atLeast {
+			t.Errorf("%q at least %d.%d.%d: expected %v", test.version, test.major, test.minor, test.build, test.atLeast)
+		}
+	}
+}
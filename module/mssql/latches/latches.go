@@ -0,0 +1,129 @@
+package latches
+
+import (
+	"context"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "latches", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("latches", fields)
+	mssql.RequirePermissions("latches", mssql.ViewServerState)
+}
+
+// Latch statistics per latch class, without the classes that never waited.
+// The BUFFER class sums up the page latches, detailed by the PAGELATCH and
+// PAGEIOLATCH wait types of the waits metricset.
+const query = `
+	SELECT latch_class, waiting_requests_count, wait_time_ms, max_wait_time_ms
+	FROM sys.dm_os_latch_stats
+	WHERE waiting_requests_count > 0
+`
+
+var fields = mssql.Field{
+	Name:        "latches",
+	Type:        "group",
+	Description: "`latches` contains the wait statistics of a latch class.",
+	Fields: []mssql.Field{
+		{Name: "class", Type: "keyword", Description: "Name of the latch class."},
+		{Name: "waiting_requests.count", Type: "long", Description: "Number of waits on latches of this class."},
+		{Name: "wait_time.ms", Type: "long", Description: "Total wait time on latches of this class."},
+		{Name: "wait_time.max.ms", Type: "long", Description: "Maximum wait time on a latch of this class."},
+		{Name: "interval.waiting_requests.count", Type: "long", Description: "Number of waits since the previous fetch."},
+		{Name: "interval.wait_time.ms", Type: "long", Description: "Wait time since the previous fetch."},
+	},
+}
+
+type latchStats struct {
+	class                              string
+	waitingRequests, waitTimeMs, maxMs int64
+
+	// Increase since the previous fetch.
+	interval []int64
+}
+
+// MetricSet reports the latch classes that waited the most since the
+// previous fetch.
+type MetricSet struct {
+	*mssql.MetricSet
+	top *mssql.Top
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Top int `config:"latches.top" validate:"min=1"`
+	}{20}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	// The latch classes that waited, by wait time.
+	return &MetricSet{MetricSet: ms, top: mssql.NewTop(config.Top, 0, 1)}, nil
+}
+
+// Fetch reports one event per latch class among the latches.top that waited
+// the most since the previous fetch. Nothing is reported on the first fetch.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+// NeedsBaseline is true until the statistics needed to compute the interval
+// values were fetched once.
+func (m *MetricSet) NeedsBaseline() bool {
+	return !m.top.Baseline()
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	stats := map[string]latchStats{}
+	err := m.Query(ctx, query, func(rows mssql.Rows) error {
+		var s latchStats
+		if err := rows.Scan(&s.class, &s.waitingRequests, &s.waitTimeMs, &s.maxMs); err != nil {
+			return err
+		}
+		stats[s.class] = s
+		m.top.Add(s.class, s.waitingRequests, s.waitTimeMs)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, increase := range m.top.Next() {
+		s := stats[increase.Key]
+		s.interval = increase.Deltas
+		if !r.Event(mb.Event{MetricSetFields: s.fields()}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s latchStats) fields() common.MapStr {
+	return common.MapStr{
+		"class": s.class,
+		"waiting_requests": common.MapStr{
+			"count": s.waitingRequests,
+		},
+		"wait_time": common.MapStr{
+			"ms":     s.waitTimeMs,
+			"max.ms": s.maxMs,
+		},
+		"interval": common.MapStr{
+			"waiting_requests": common.MapStr{"count": s.interval[0]},
+			"wait_time":        common.MapStr{"ms": s.interval[1]},
+		},
+	}
+}
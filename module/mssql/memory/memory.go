@@ -0,0 +1,341 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "memory", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("memory", fields)
+	mssql.RequirePermissions("memory", mssql.ViewServerState)
+}
+
+// Memory of the SQL Server process and of the machine, with the memory
+// pressure signals of both.
+const memoryQuery = `
+	SELECT
+		p.physical_memory_in_use_kb,
+		p.locked_page_allocations_kb,
+		p.large_page_allocations_kb,
+		p.memory_utilization_percentage,
+		p.available_commit_limit_kb,
+		p.page_fault_count,
+		p.process_physical_memory_low,
+		p.process_virtual_memory_low,
+		s.total_physical_memory_kb,
+		s.available_physical_memory_kb,
+		s.total_page_file_kb,
+		s.available_page_file_kb,
+		s.system_cache_kb,
+		s.system_high_memory_signal_state,
+		s.system_low_memory_signal_state,
+		s.system_memory_state_desc
+	FROM sys.dm_os_process_memory AS p
+	CROSS JOIN sys.dm_os_sys_memory AS s
+`
+
+// Memory clerks grouped by type, a type has one clerk per memory node.
+const clerksQuery = `
+	SELECT type, SUM(pages_kb), SUM(virtual_memory_committed_kb), SUM(awe_allocated_kb)
+	FROM sys.dm_os_memory_clerks
+	GROUP BY type
+`
+
+// Pages of the buffer pool per database. It reads a row per page, so it is
+// only run every buffer_pool.interval on buffer pools below
+// buffer_pool.max_size_mb.
+const bufferPoolQuery = `
+	SELECT
+		CASE database_id WHEN 32767 THEN 'mssqlsystemresource' ELSE DB_NAME(database_id) END,
+		COUNT_BIG(*),
+		SUM(CAST(is_modified AS bigint))
+	FROM sys.dm_os_buffer_descriptors
+	GROUP BY database_id
+`
+
+// bufferPoolClerk is the clerk of the buffer pool, its size is checked before
+// reading the buffer descriptors.
+const bufferPoolClerk = "MEMORYCLERK_SQLBUFFERPOOL"
+
+var fields = mssql.Field{
+	Name:        "memory",
+	Type:        "group",
+	Description: "`memory` contains the memory usage of the server, of a memory clerk, or of the buffer pool pages of a database.",
+	Fields: []mssql.Field{
+		{Name: "process.physical_memory_in_use.kb", Type: "long", Description: "Physical memory used by the SQL Server process, including the large and locked pages."},
+		{Name: "process.locked_page_allocations.kb", Type: "long", Description: "Memory allocated with locked pages."},
+		{Name: "process.large_page_allocations.kb", Type: "long", Description: "Memory allocated with large pages."},
+		{Name: "process.utilization.pct", Type: "scaled_float", Format: "percent", Description: "Percentage of the committed memory held in the working set of the process."},
+		{Name: "process.available_commit_limit.kb", Type: "long", Description: "Memory the process can still commit."},
+		{Name: "process.page_faults", Type: "long", Description: "Number of page faults of the process since it started."},
+		{Name: "process.physical_memory_low", Type: "boolean", Description: "Whether the process is responding to a low physical memory notification."},
+		{Name: "process.virtual_memory_low", Type: "boolean", Description: "Whether the process is running low on virtual address space."},
+		{Name: "system.total.kb", Type: "long", Description: "Physical memory of the machine."},
+		{Name: "system.available.kb", Type: "long", Description: "Physical memory available on the machine."},
+		{Name: "system.page_file.total.kb", Type: "long", Description: "Commit limit of the machine, the physical memory and the page files."},
+		{Name: "system.page_file.available.kb", Type: "long", Description: "Memory that can still be committed on the machine."},
+		{Name: "system.cache.kb", Type: "long", Description: "System cache memory of the machine."},
+		{Name: "system.high_memory_signal", Type: "boolean", Description: "Whether the operating system signals a high available physical memory."},
+		{Name: "system.low_memory_signal", Type: "boolean", Description: "Whether the operating system signals a low available physical memory."},
+		{Name: "system.memory_state", Type: "keyword", Description: "State of the physical memory of the machine, as described by the operating system."},
+		{Name: "clerk.type", Type: "keyword", Description: "Type of the memory clerk."},
+		{Name: "clerk.pages.kb", Type: "long", Description: "Memory allocated by the clerks of this type."},
+		{Name: "clerk.virtual_memory_committed.kb", Type: "long", Description: "Virtual memory committed by the clerks of this type."},
+		{Name: "clerk.awe_allocated.kb", Type: "long", Description: "Memory locked in physical memory by the clerks of this type."},
+		{Name: "buffer_pool.pages", Type: "long", Description: "Number of pages of the database in the buffer pool."},
+		{Name: "buffer_pool.size.kb", Type: "long", Description: "Size of the pages of the database in the buffer pool."},
+		{Name: "buffer_pool.modified_pages", Type: "long", Description: "Number of pages of the database modified in the buffer pool and not yet written to disk."},
+	},
+}
+
+type config struct {
+	// Top is the number of memory clerk types reported, the largest.
+	Top        int `config:"memory.clerks.top" validate:"min=0"`
+	BufferPool struct {
+		Interval  time.Duration `config:"interval" validate:"positive"`
+		MaxSizeMB int64         `config:"max_size_mb" validate:"min=0"`
+	} `config:"memory.buffer_pool"`
+}
+
+func defaultConfig() config {
+	c := config{Top: 10}
+	c.BufferPool.Interval = 10 * time.Minute
+	c.BufferPool.MaxSizeMB = 8 * 1024
+	return c
+}
+
+// MetricSet reports the memory usage of the server, of its largest memory
+// clerks, and the pages of every database in the buffer pool.
+type MetricSet struct {
+	*mssql.MetricSet
+	config config
+
+	lastBufferPool time.Time
+	// bufferPoolTooLarge is set while the buffer pool is too large to be
+	// read, so that it is only logged when it changes.
+	bufferPoolTooLarge bool
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, config: config}, nil
+}
+
+// Fetch reports an event with the memory of the process and the machine, an
+// event per memory clerk type among the largest, and every
+// buffer_pool.interval an event per database in the buffer pool.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	var mem memoryState
+	err := m.Query(ctx, memoryQuery, func(rows mssql.Rows) error {
+		return rows.Scan(&mem.physicalInUse, &mem.lockedPages, &mem.largePages, &mem.utilization,
+			&mem.availableCommit, &mem.pageFaults, &mem.physicalLow, &mem.virtualLow,
+			&mem.systemTotal, &mem.systemAvailable, &mem.pageFileTotal, &mem.pageFileAvailable,
+			&mem.systemCache, &mem.highSignal, &mem.lowSignal, &mem.systemState)
+	})
+	if err != nil {
+		return err
+	}
+	if !r.Event(mb.Event{MetricSetFields: mem.fields()}) {
+		return nil
+	}
+
+	clerks, err := m.queryClerks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range topClerks(clerks, m.config.Top) {
+		if !r.Event(mb.Event{MetricSetFields: c.fields()}) {
+			return nil
+		}
+	}
+
+	if !m.lastBufferPool.IsZero() && time.Since(m.lastBufferPool) < m.config.BufferPool.Interval {
+		return nil
+	}
+	tooLarge := m.tooLarge(clerks)
+	if tooLarge && !m.bufferPoolTooLarge {
+		logp.Info("Buffer pool of %s is larger than memory.buffer_pool.max_size_mb, its pages per database are not collected",
+			m.HostData().SanitizedURI)
+	}
+	m.bufferPoolTooLarge = tooLarge
+	if tooLarge {
+		return nil
+	}
+	pools, err := m.queryBufferPool(ctx)
+	if err != nil {
+		return err
+	}
+	m.lastBufferPool = time.Now()
+	for _, p := range pools {
+		event := mb.Event{
+			ModuleFields: common.MapStr{
+				"database": common.MapStr{
+					"name": p.database,
+				},
+			},
+			MetricSetFields: p.fields(),
+		}
+		if !r.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// tooLarge tells whether the buffer pool is too large to read its pages.
+func (m *MetricSet) tooLarge(clerks []clerk) bool {
+	if m.config.BufferPool.MaxSizeMB == 0 {
+		return false
+	}
+	for _, c := range clerks {
+		if c.clerkType == bufferPoolClerk {
+			return c.pagesKB > m.config.BufferPool.MaxSizeMB*1024
+		}
+	}
+	return false
+}
+
+// memoryState is the memory of the process and of the machine.
+type memoryState struct {
+	physicalInUse, lockedPages, largePages, utilization int64
+	availableCommit, pageFaults                         int64
+	physicalLow, virtualLow                             bool
+	systemTotal, systemAvailable                        int64
+	pageFileTotal, pageFileAvailable, systemCache       int64
+	highSignal, lowSignal                               bool
+	systemState                                         string
+}
+
+func (s memoryState) fields() common.MapStr {
+	return common.MapStr{
+		"process": common.MapStr{
+			"physical_memory_in_use":  common.MapStr{"kb": s.physicalInUse},
+			"locked_page_allocations": common.MapStr{"kb": s.lockedPages},
+			"large_page_allocations":  common.MapStr{"kb": s.largePages},
+			"utilization":             common.MapStr{"pct": float64(s.utilization) / 100},
+			"available_commit_limit":  common.MapStr{"kb": s.availableCommit},
+			"page_faults":             s.pageFaults,
+			"physical_memory_low":     s.physicalLow,
+			"virtual_memory_low":      s.virtualLow,
+		},
+		"system": common.MapStr{
+			"total":     common.MapStr{"kb": s.systemTotal},
+			"available": common.MapStr{"kb": s.systemAvailable},
+			"page_file": common.MapStr{
+				"total":     common.MapStr{"kb": s.pageFileTotal},
+				"available": common.MapStr{"kb": s.pageFileAvailable},
+			},
+			"cache":              common.MapStr{"kb": s.systemCache},
+			"high_memory_signal": s.highSignal,
+			"low_memory_signal":  s.lowSignal,
+			"memory_state":       s.systemState,
+		},
+	}
+}
+
+type clerk struct {
+	clerkType                 string
+	pagesKB, virtualKB, aweKB int64
+}
+
+func (c clerk) fields() common.MapStr {
+	return common.MapStr{
+		"clerk": common.MapStr{
+			"type":                     c.clerkType,
+			"pages":                    common.MapStr{"kb": c.pagesKB},
+			"virtual_memory_committed": common.MapStr{"kb": c.virtualKB},
+			"awe_allocated":            common.MapStr{"kb": c.aweKB},
+		},
+	}
+}
+
+// topClerks returns the n clerk types allocating the most pages.
+func topClerks(clerks []clerk, n int) []clerk {
+	sorted := append([]clerk(nil), clerks...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].pagesKB != sorted[j].pagesKB {
+			return sorted[i].pagesKB > sorted[j].pagesKB
+		}
+		return sorted[i].clerkType < sorted[j].clerkType
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+	return sorted
+}
+
+func (m *MetricSet) queryClerks(ctx context.Context) ([]clerk, error) {
+	var clerks []clerk
+	err := m.Query(ctx, clerksQuery, func(rows mssql.Rows) error {
+		var c clerk
+		if err := rows.Scan(&c.clerkType, &c.pagesKB, &c.virtualKB, &c.aweKB); err != nil {
+			return err
+		}
+		clerks = append(clerks, c)
+		return nil
+	})
+
+	return clerks, err
+}
+
+type bufferPool struct {
+	database      string
+	pages         int64
+	modifiedPages int64
+}
+
+func (p bufferPool) fields() common.MapStr {
+	return common.MapStr{
+		"buffer_pool": common.MapStr{
+			"pages":          p.pages,
+			"size":           common.MapStr{"kb": p.pages * 8},
+			"modified_pages": p.modifiedPages,
+		},
+	}
+}
+
+func (m *MetricSet) queryBufferPool(ctx context.Context) ([]bufferPool, error) {
+	var pools []bufferPool
+	err := m.Query(ctx, bufferPoolQuery, func(rows mssql.Rows) error {
+		var database sql.NullString
+		var p bufferPool
+		if err := rows.Scan(&database, &p.pages, &p.modifiedPages); err != nil {
+			return err
+		}
+		// Pages of a database dropped since they were read.
+		if !database.Valid {
+			return nil
+		}
+		p.database = database.String
+		pools = append(pools, p)
+		return nil
+	})
+
+	return pools, err
+}
@@ -0,0 +1,39 @@
+// +build !integration
+
+package memory
+
+import (
+	"testing"
+)
+
+func TestTopClerks(t *testing.T) {
+	clerks := []clerk{
+		{clerkType: "CACHESTORE_SQLCP", pagesKB: 200},
+		{clerkType: bufferPoolClerk, pagesKB: 1000},
+		{clerkType: "CACHESTORE_OBJCP", pagesKB: 200},
+		{clerkType: "MEMORYCLERK_SQLGENERAL", pagesKB: 10},
+	}
+	top := topClerks(clerks, 3)
+	if len(top) != 3 || top[0].clerkType != bufferPoolClerk || top[1].clerkType != "CACHESTORE_OBJCP" || top[2].clerkType != "CACHESTORE_SQLCP" {
+		t.Errorf("unexpected top clerks %v", top)
+	}
+	if clerks[0].clerkType != "CACHESTORE_SQLCP" {
+		t.Error("topClerks modified its argument")
+	}
+}
+
+func TestTooLarge(t *testing.T) {
+	m := &MetricSet{config: defaultConfig()}
+	clerks := []clerk{{clerkType: bufferPoolClerk, pagesKB: 1024 * 1024}}
+	if m.tooLarge(clerks) {
+		t.Error("expected a 1 GB buffer pool to be read")
+	}
+	m.config.BufferPool.MaxSizeMB = 512
+	if !m.tooLarge(clerks) {
+		t.Error("expected a buffer pool larger than max_size_mb not to be read")
+	}
+	m.config.BufferPool.MaxSizeMB = 0
+	if m.tooLarge(clerks) {
+		t.Error("expected no limit with max_size_mb 0")
+	}
+}
@@ -0,0 +1,334 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/prometheus"
+)
+
+// MetricSet is the base of the mssql metricsets. It holds the connection pool
+// to the host, tracks the collection health statistics of the metricset and
+// adds the instance metadata to its events.
+type MetricSet struct {
+	mb.BaseMetricSet
+	DB       *sql.DB
+	Stats    *Stats
+	Instance *Instance
+
+	// querier runs the queries, on DB unless another one is set.
+	querier Querier
+
+	// Permissions the login lacks, checked again after permissionsRefresh.
+	missing            []string
+	permissionsChecked time.Time
+	permissionsRefresh time.Duration
+}
+
+// NewMetricSet opens the connection pool to the host of the metricset and
+// registers its metrics.
+func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
+	config := struct {
+		InstanceRefresh time.Duration   `config:"instance_refresh" validate:"positive"`
+		Recording       RecordingConfig `config:"recording"`
+	}{DefaultInstanceRefresh, DefaultRecordingConfig}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	db, err := NewConnection(base.HostData())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &MetricSet{
+		BaseMetricSet:      base,
+		DB:                 db,
+		querier:            DBQuerier{DB: db},
+		permissionsRefresh: config.InstanceRefresh,
+	}
+	m.Stats = NewStats(&base, db)
+	if config.Recording.Enabled {
+		m.querier = NewRecordingQuerier(db, m.Stats.key, config.Recording)
+	}
+	m.Instance = acquireInstance(base.HostData().URI, config.InstanceRefresh)
+	return m, nil
+}
+
+// Baseliner is implemented by the metricsets reporting values computed over
+// the interval between two fetches. NeedsBaseline is true until the metricset
+// fetched once, when these values are still missing from its events.
+type Baseliner interface {
+	NeedsBaseline() bool
+}
+
+// disabled tells whether the login lacks permissions required by the
+// metricset. They are checked on the first fetch and then every
+// permissionsRefresh. Changes are logged, so it is clear why a metricset does
+// not report anything. When they cannot be checked the fetch runs, and reports
+// the error.
+func (m *MetricSet) disabled(ctx context.Context) bool {
+	required := RequiredPermissions(m.Name())
+	if len(required) == 0 {
+		return false
+	}
+	if !m.permissionsChecked.IsZero() && time.Since(m.permissionsChecked) < m.permissionsRefresh {
+		return len(m.missing) > 0
+	}
+
+	missing, err := MissingPermissions(ctx, m, required)
+	if err != nil {
+		logp.Debug("mssql", "Failed to check the permissions of %s on %s: %v", m.Name(), m.HostData().SanitizedURI, err)
+		return false
+	}
+	m.permissionsChecked = time.Now()
+
+	switch {
+	case len(missing) > 0 && len(m.missing) == 0:
+		logp.Warn("Metricset %s is disabled on %s, the login lacks %s", m.Name(), m.HostData().SanitizedURI, strings.Join(missing, ", "))
+	case len(missing) == 0 && len(m.missing) > 0:
+		logp.Info("Metricset %s is enabled again on %s, the login was granted %s", m.Name(), m.HostData().SanitizedURI, strings.Join(m.missing, ", "))
+	}
+	m.missing = missing
+	m.Stats.PermissionsChecked(missing)
+	return len(missing) > 0
+}
+
+// PermissionsMissing returns the permissions the login lacked when they were
+// last checked.
+func (m *MetricSet) PermissionsMissing() []string {
+	return m.missing
+}
+
+// FetchFunc collects the data of a fetch and reports it as events.
+type FetchFunc func(ctx context.Context, r mb.ReporterV2) error
+
+// Fetch runs fetch bounded by the module timeout and records its outcome.
+// Errors returned by fetch are reported as an error event. The metricset does
+// not fetch while the login lacks the permissions it requires.
+func (m *MetricSet) Fetch(r mb.ReporterV2, fetch FetchFunc) {
+	m.Stats.FetchStarted(time.Now())
+
+	timeout := m.Module().Config().Timeout
+	ctx, cancel := FetchContext(r, timeout)
+	defer cancel()
+
+	if m.disabled(ctx) {
+		return
+	}
+
+	reporter := &metricSetReporter{
+		ReporterV2: r,
+		ms:         m,
+		instance:   m.Instance.Fields(ctx, m),
+	}
+	err := fetch(ctx, reporter)
+	if err != nil {
+		if IsPermissionDenied(err) {
+			// Permissions were revoked, check them before the next fetch.
+			m.permissionsChecked = time.Time{}
+		}
+		err = FetchError(ctx, timeout, err)
+		reporter.Error(err)
+	}
+	m.Stats.FetchDone(err)
+}
+
+// ProbeFunc checks that the server is reachable and returns the fields of the
+// event reporting the outcome of the check.
+type ProbeFunc func(ctx context.Context) (common.MapStr, error)
+
+// Probe runs probe bounded by the module timeout and reports its outcome as
+// one event. The probe runs first, with its own deadline, so that the queries
+// of the instance metadata neither delay nor fail it. When it fails, the
+// error of the event is set and the fetch is counted as failed; the event
+// only carries the metadata cached by the previous fetches.
+func (m *MetricSet) Probe(r mb.ReporterV2, probe ProbeFunc) {
+	m.Stats.FetchStarted(time.Now())
+
+	timeout := m.Module().Config().Timeout
+	ctx, cancel := FetchContext(r, timeout)
+	fields, err := probe(ctx)
+	if err != nil {
+		err = FetchError(ctx, timeout, err)
+	}
+	cancel()
+
+	reporter := &metricSetReporter{ReporterV2: r, ms: m}
+	if err != nil {
+		reporter.instance = m.Instance.cached()
+	} else {
+		ctx, cancel := FetchContext(r, timeout)
+		defer cancel()
+		reporter.instance = m.Instance.Fields(ctx, m)
+	}
+	reporter.Event(mb.Event{MetricSetFields: fields, Error: err})
+	m.Stats.FetchDone(err)
+}
+
+// Query runs a query and calls scan for every row of the result. The
+// MetricSet is the Querier of the metricsets, it records the query durations
+// and rows in the stats.
+func (m *MetricSet) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	return m.query(ctx, m.querier, query, scan, args...)
+}
+
+func (m *MetricSet) query(ctx context.Context, q Querier, query string, scan RowScanner, args ...interface{}) error {
+	start := time.Now()
+	count := 0
+	defer func() { m.Stats.QueryDone(time.Since(start), count) }()
+
+	return q.Query(ctx, query, func(rows Rows) error {
+		count++
+		return scan(rows)
+	}, args...)
+}
+
+// Connect opens a dedicated connection through the querier of the metricset,
+// for the checks measuring the login. Its queries and pings are recorded in
+// the stats like those of Query.
+func (m *MetricSet) Connect(ctx context.Context) (Conn, error) {
+	connector, ok := m.querier.(Connector)
+	if !ok {
+		return nil, fmt.Errorf("querier %T cannot open connections", m.querier)
+	}
+	conn, err := connector.Connect(ctx, m.HostData())
+	if err != nil {
+		return nil, err
+	}
+	return &metricSetConn{Conn: conn, ms: m}, nil
+}
+
+// metricSetConn records the queries of a Conn in the stats of the metricset.
+type metricSetConn struct {
+	Conn
+	ms *MetricSet
+}
+
+func (c *metricSetConn) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	return c.ms.query(ctx, c.Conn, query, scan, args...)
+}
+
+func (c *metricSetConn) Ping(ctx context.Context) (time.Duration, error) {
+	took, err := c.Conn.Ping(ctx)
+	count := 0
+	if err == nil {
+		count = 1
+	}
+	c.ms.Stats.QueryDone(took, count)
+	return took, err
+}
+
+// Querier returns the querier running the queries of the metricset.
+func (m *MetricSet) Querier() Querier {
+	return m.querier
+}
+
+// SetQuerier replaces the querier running the queries on the connection pool,
+// for example with a ReplayQuerier in tests.
+func (m *MetricSet) SetQuerier(q Querier) {
+	m.querier = q
+}
+
+// Replay makes the metricset answer its queries with the result sets of the
+// recordings in paths instead of querying the server.
+func (m *MetricSet) Replay(paths ...string) error {
+	recording, err := LoadRecording(paths...)
+	if err != nil {
+		return err
+	}
+	m.closeQuerier()
+	m.SetQuerier(NewReplayQuerier(recording))
+	return nil
+}
+
+// RecordingName returns the name of the file the metricset records its result
+// sets to, when recording is enabled.
+func (m *MetricSet) RecordingName() string {
+	return recordingName(m.Stats.key)
+}
+
+func (m *MetricSet) closeQuerier() {
+	if closer, ok := m.querier.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// PrometheusEnabled tells whether the metricset should build samples for the
+// Prometheus listener.
+func (m *MetricSet) PrometheusEnabled() bool {
+	return prometheus.Default.Enabled()
+}
+
+// Publish replaces the samples exposed to Prometheus by the metricset. The
+// samples are labeled with the server name, or the host until the instance
+// metadata is known.
+func (m *MetricSet) Publish(samples []prometheus.Sample) {
+	server := m.Instance.ServerName()
+	if server == "" {
+		server = m.Host()
+	}
+	for i := range samples {
+		labels := map[string]string{"server": server}
+		for k, v := range samples[i].Labels {
+			labels[k] = v
+		}
+		samples[i].Labels = labels
+	}
+	prometheus.Default.Set(m.Stats.key, samples)
+}
+
+// Close closes the connection pool and removes the metrics.
+func (m *MetricSet) Close() error {
+	prometheus.Default.Remove(m.Stats.key)
+	m.closeQuerier()
+	m.Stats.Close()
+	m.Instance.release()
+	return m.DB.Close()
+}
+
+// metricSetReporter adds the instance metadata to the events of a fetch and
+// counts them. It keeps the done channel of push reporters available to
+// FetchContext.
+type metricSetReporter struct {
+	mb.ReporterV2
+	ms       *MetricSet
+	instance common.MapStr
+}
+
+func (r *metricSetReporter) Event(event mb.Event) bool {
+	if event.Error != nil {
+		r.ms.Instance.Invalidate()
+	}
+	if r.instance != nil {
+		if event.ModuleFields == nil {
+			event.ModuleFields = common.MapStr{}
+		}
+		event.ModuleFields.Put("instance", r.instance.Clone())
+	}
+
+	if !r.ReporterV2.Event(event) {
+		return false
+	}
+	r.ms.Stats.EventPublished()
+	return true
+}
+
+func (r *metricSetReporter) Error(err error) bool {
+	return r.Event(mb.Event{Error: err})
+}
+
+func (r *metricSetReporter) Done() <-chan struct{} {
+	if pr, ok := r.ReporterV2.(mb.PushReporterV2); ok {
+		return pr.Done()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// +build !integration
+
+package mssql_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/elastic/beats/metricbeat/mb"
+
+	_ "github.com/mathenning/mssqlbeat/include"
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/module/mssql/mtest"
+)
+
+// fetches is the number of fetches of the metricsets before their events are
+// compared with the golden files, two for those reporting the values of the
+// interval between fetches or the records added since the previous fetch.
+var fetches = map[string]int{
+	"availability":    1,
+	"cpu":             2,
+	"identity":        1,
+	"indexes":         1,
+	"latches":         2,
+	"memory":          1,
+	"performance":     2,
+	"schedulers":      1,
+	"spinlocks":       2,
+	"statistics":      1,
+	"tempdb":          1,
+	"transaction_log": 1,
+	"transactions":    1,
+	"waits":           2,
+}
+
+// TestMetricSets checks the fields.yml and the golden events of every
+// registered metricset. Update them with the -fields and -golden flags.
+func TestMetricSets(t *testing.T) {
+	names := mb.Registry.MetricSets(mssql.ModuleName)
+	sort.Strings(names)
+	if len(names) == 0 {
+		t.Fatal("no metricset registered")
+	}
+
+	t.Run("fields", func(t *testing.T) {
+		for _, name := range names {
+			name := name
+			t.Run(name, func(t *testing.T) {
+				fields, found := mssql.MetricSetFields(name)
+				if !found {
+					t.Fatal("fields not registered")
+				}
+				mtest.CheckFieldsYAML(t, name, mssql.FieldsYAML(fields))
+			})
+		}
+	})
+
+	t.Run("recordings", func(t *testing.T) {
+		for _, name := range names {
+			name := name
+			t.Run(name, func(t *testing.T) {
+				n, found := fetches[name]
+				if !found {
+					t.Fatal("number of fetches not set")
+				}
+				mtest.CheckRecordings(t, name, n)
+			})
+		}
+	})
+}
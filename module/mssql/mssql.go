@@ -0,0 +1,124 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/metricbeat/mb"
+	"github.com/elastic/beats/metricbeat/mb/parse"
+
+	// Register the SQL Server database/sql driver
+	_ "github.com/denisenkom/go-mssqldb"
+)
+
+// ModuleName is the name the module is registered with.
+const ModuleName = "mssql"
+
+func init() {
+	// Register the ModuleFactory function for the "mssql" module.
+	if err := mb.Registry.AddModule(ModuleName, NewModule); err != nil {
+		panic(err)
+	}
+}
+
+// NewModule validates the module configuration.
+func NewModule(base mb.BaseModule) (mb.Module, error) {
+	// Validate that at least one host has been specified.
+	config := struct {
+		Hosts []string `config:"hosts"    validate:"nonzero,required"`
+	}{}
+	if err := base.UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	return &base, nil
+}
+
+// ParseURL parses a host of the form
+// sqlserver://[user[:password]@]host[:port][/instance][?param=value] into
+// HostData. The scheme can be omitted, and the username and password can also
+// be set with the module's username and password options.
+func ParseURL(mod mb.Module, rawURL string) (mb.HostData, error) {
+	c := struct {
+		Username string `config:"username"`
+		Password string `config:"password"`
+	}{}
+	if err := mod.UnpackConfig(&c); err != nil {
+		return mb.HostData{}, err
+	}
+
+	if parts := strings.SplitN(rawURL, "://", 2); len(parts) != 2 {
+		// Add scheme.
+		rawURL = fmt.Sprintf("sqlserver://%s", rawURL)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return mb.HostData{}, fmt.Errorf("error parsing URL: %v", err)
+	}
+	if u.Scheme != "sqlserver" {
+		return mb.HostData{}, fmt.Errorf("unsupported scheme %q, use sqlserver://", u.Scheme)
+	}
+
+	parse.SetURLUser(u, c.Username, c.Password)
+
+	q := u.Query()
+	if q.Get("app name") == "" {
+		q.Set("app name", "mssqlbeat")
+	}
+	u.RawQuery = q.Encode()
+
+	return parse.NewHostDataFromURL(u), nil
+}
+
+// NewConnection opens a connection pool to the SQL Server described by the
+// given host data. Connections are established lazily by the pool.
+func NewConnection(host mb.HostData) (*sql.DB, error) {
+	db, err := sql.Open("sqlserver", host.URI)
+	if err != nil {
+		return nil, fmt.Errorf("could not open connection to %s: %v", host.SanitizedURI, err)
+	}
+
+	// Metricsets run a handful of queries per cycle, there is no need to
+	// hold more than a couple of sessions open on the server.
+	db.SetMaxOpenConns(2)
+	db.SetMaxIdleConns(1)
+
+	return db, nil
+}
+
+// FetchContext returns a context for a single fetch. It expires after the
+// module timeout, which defaults to the period, and is cancelled when the
+// reporter is closed so that stopping the beat interrupts running queries.
+func FetchContext(r mb.ReporterV2, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	if pr, ok := r.(mb.PushReporterV2); ok {
+		go func() {
+			select {
+			case <-pr.Done():
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	return ctx, cancel
+}
+
+// FetchError converts a query error into the error reported for a fetch. Timeouts
+// are reported explicitly, as the driver error for an interrupted query does not
+// tell why it was interrupted.
+func FetchError(ctx context.Context, timeout time.Duration, err error) error {
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return fmt.Errorf("query timed out after %v: %v", timeout, err)
+	case context.Canceled:
+		return fmt.Errorf("query cancelled: %v", err)
+	}
+	return err
+}
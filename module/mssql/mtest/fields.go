@@ -0,0 +1,116 @@
+// Package mtest contains helpers for the tests of the mssql module and its
+// metricsets.
+package mtest
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// Use `go test -fields` to update the fields.yml files.
+var fieldsFlag = flag.Bool("fields", false, "Write updated fields.yml files")
+
+// moduleDir returns the directory of the mssql module, so that its files are
+// found from the tests of the module and of its metricsets.
+func moduleDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(filepath.Dir(file))
+}
+
+// CheckFieldsYAML fails the test when _meta/fields.yml of the metricset, or
+// of the module when metricset is empty, differs from the one generated from
+// the field definitions. With the -fields flag the file is written instead.
+func CheckFieldsYAML(t testing.TB, metricset string, generated []byte) {
+	path := filepath.Join(moduleDir(), metricset, "_meta", "fields.yml")
+	if *fieldsFlag {
+		if err := ioutil.WriteFile(path, generated, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	current, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(current) != string(generated) {
+		t.Errorf("%s is out of date, run go test -fields to update it", path)
+	}
+}
+
+// CheckEventFields fails the test for every field of the event in the mssql
+// namespace that is not declared in the fields.yml files of the module and of
+// the metricset. Events of the module package itself have an empty
+// metricset.
+func CheckEventFields(t testing.TB, metricset string, event mb.Event) {
+	declared := map[string]common.Field{}
+	loadFields(t, declared, "", filepath.Join(moduleDir(), "_meta", "fields.yml"))
+	if metricset != "" {
+		loadFields(t, declared, "mssql."+metricset, filepath.Join(moduleDir(), metricset, "_meta", "fields.yml"))
+	}
+
+	var emitted []string
+	for key := range event.ModuleFields.Flatten() {
+		emitted = append(emitted, "mssql."+key)
+	}
+	for key := range event.MetricSetFields.Flatten() {
+		emitted = append(emitted, "mssql."+metricset+"."+key)
+	}
+
+	for _, key := range emitted {
+		if !isDeclared(declared, key) {
+			t.Errorf("field %s is not declared in fields.yml", key)
+		}
+	}
+}
+
+// loadFields adds the fields declared in a fields.yml to declared. The
+// top level entry of the file, the key of a module or the group of a
+// metricset, is dropped on load, so its fields are added under namespace.
+func loadFields(t testing.TB, declared map[string]common.Field, namespace, path string) {
+	fields, err := common.LoadFieldsYaml(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addFields(declared, namespace, fields)
+}
+
+func addFields(declared map[string]common.Field, namespace string, fields common.Fields) {
+	for _, f := range fields {
+		name := f.Name
+		if namespace != "" {
+			name = namespace + "." + f.Name
+		}
+		if len(f.Fields) > 0 {
+			addFields(declared, name, f.Fields)
+			continue
+		}
+		declared[name] = f
+	}
+}
+
+// isDeclared tells whether a flattened event key is declared, either exactly,
+// by a wildcard or by an object field containing it.
+func isDeclared(declared map[string]common.Field, key string) bool {
+	if _, found := declared[key]; found {
+		return true
+	}
+	for name, f := range declared {
+		if f.Type == "object" && strings.HasPrefix(key, name+".") {
+			return true
+		}
+		if strings.Contains(name, "*") {
+			if matched, _ := filepath.Match(name, key); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
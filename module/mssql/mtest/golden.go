@@ -0,0 +1,136 @@
+package mtest
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+	mbtest "github.com/elastic/beats/metricbeat/mb/testing"
+)
+
+// Use `go test -golden` to update the golden event files.
+var goldenFlag = flag.Bool("golden", false, "Write updated golden event files")
+
+// replayer is implemented by the mssql metricsets.
+type replayer interface {
+	Replay(paths ...string) error
+}
+
+// Recordings returns the recorded results of the SQL Server versions the
+// metricsets are tested against, the files in _meta/testdata of the module.
+func Recordings(t testing.TB) []string {
+	files, err := filepath.Glob(filepath.Join(moduleDir(), "_meta", "testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no recordings found")
+	}
+	return files
+}
+
+// FetchRecorded fetches the metricset the given number of times, with the
+// queries answered from the recording, and returns the events of the last
+// fetch. Metricsets computing values over an interval need two fetches.
+func FetchRecorded(t testing.TB, metricset, recording string, fetches int) []mb.Event {
+	name := strings.TrimSuffix(filepath.Base(recording), ".json")
+	ms := mbtest.NewReportingMetricSetV2(t, map[string]interface{}{
+		"module":     "mssql",
+		"metricsets": []string{metricset},
+		"hosts":      []string{"sqlserver://" + name},
+	})
+	if closer, ok := ms.(mb.Closer); ok {
+		defer closer.Close()
+	}
+	if err := ms.(replayer).Replay(recording); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []mb.Event
+	for i := 0; i < fetches; i++ {
+		events, _ = mbtest.ReportingFetchV2(ms)
+	}
+	return events
+}
+
+// CheckRecordings fetches the metricset with every recording and compares
+// the events with the golden files in _meta/testdata of the metricset, named
+// after the recordings. The fields of the events must be declared in fields.yml. With
+// the -golden flag the golden files are written instead.
+func CheckRecordings(t *testing.T, metricset string, fetches int) {
+	for _, recording := range Recordings(t) {
+		name := strings.TrimSuffix(filepath.Base(recording), ".json")
+		t.Run(name, func(t *testing.T) {
+			events := FetchRecorded(t, metricset, recording, fetches)
+			if len(events) == 0 {
+				t.Fatal("no event reported")
+			}
+			for _, e := range events {
+				CheckEventFields(t, metricset, e)
+			}
+			checkGolden(t, filepath.Join(moduleDir(), metricset, "_meta", "testdata", name+".golden.json"), metricset, events)
+		})
+	}
+}
+
+func checkGolden(t *testing.T, path, metricset string, events []mb.Event) {
+	docs := make([]json.RawMessage, len(events))
+	for i, e := range events {
+		data, err := json.Marshal(goldenDocument(metricset, e))
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs[i] = data
+	}
+	// Some metricsets report their events in map order.
+	sort.Slice(docs, func(i, j int) bool { return string(docs[i]) < string(docs[j]) })
+
+	generated, err := json.MarshalIndent(docs, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated = append(generated, '\n')
+
+	if *goldenFlag {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, generated, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	current, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(current) != string(generated) {
+		t.Errorf("events differ from %s, run go test -golden to update it if the change is expected. Events:\n%s", path, generated)
+	}
+}
+
+// goldenDocument returns the part of the event produced by the metricset.
+func goldenDocument(metricset string, e mb.Event) common.MapStr {
+	doc := common.MapStr{}
+	if e.RootFields != nil {
+		doc.DeepUpdate(e.RootFields)
+	}
+	if len(e.ModuleFields) > 0 {
+		doc.Put("mssql", e.ModuleFields.Clone())
+	}
+	if len(e.MetricSetFields) > 0 {
+		doc.Put("mssql."+metricset, e.MetricSetFields)
+	}
+	if e.Error != nil {
+		doc.Put("error.message", e.Error.Error())
+	}
+	return doc
+}
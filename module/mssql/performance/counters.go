@@ -0,0 +1,189 @@
+package performance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+// counter describes a counter of sys.dm_os_performance_counters collected by
+// the metricset. The query and the fields.yml of the metricset are generated
+// from these definitions.
+type counter struct {
+	name string
+
+	// byInstance is set for the counters reported per counter instance, for
+	// example per resource pool. Their field is an object keyed by the
+	// instance name.
+	byInstance bool
+}
+
+var counters = []counter{
+	{name: "Active memory grant amount (KB)"},
+	{name: "Active Temp Tables"},
+	{name: "Average Latch Wait Time (ms)"},
+	{name: "Average Wait Time (ms)", byInstance: true},
+	{name: "Avg Disk Read IO (ms)", byInstance: true},
+	{name: "Avg Disk Write IO (ms)", byInstance: true},
+	{name: "Avg Dist From EOL/LP Request"},
+	{name: "Avg time delete FileTable item"},
+	{name: "Avg time FileTable enumeration"},
+	{name: "Avg time FileTable handle kill"},
+	{name: "Avg time move FileTable item"},
+	{name: "Avg time per file I/O request"},
+	{name: "Avg time per file I/O response"},
+	{name: "Avg time rename FileTable item"},
+	{name: "Avg time to get FileTable item"},
+	{name: "Avg time update FileTable item"},
+	{name: "Avg. Bytes/Read", byInstance: true},
+	{name: "Avg. Bytes/Transfer", byInstance: true},
+	{name: "Avg. Bytes/Write", byInstance: true},
+	{name: "Avg. Length of Batched Writes"},
+	{name: "Avg. microsec/Read", byInstance: true},
+	{name: "Avg. microsec/Read Comp", byInstance: true},
+	{name: "Avg. microsec/Transfer", byInstance: true},
+	{name: "Avg. microsec/Write", byInstance: true},
+	{name: "Avg. microsec/Write Comp", byInstance: true},
+	{name: "Avg. Time Between Batches (ms)"},
+	{name: "Avg. Time to Write Batch (ms)"},
+	{name: "Background Writer pages/sec"},
+	{name: "Backup/Restore Throughput/sec"},
+	{name: "Batch Requests/sec"},
+	{name: "Blocked tasks"},
+	{name: "Buffer cache hit ratio"},
+	{name: "Bytes Received from Replica/sec"},
+	{name: "Bytes Sent to Replica/sec"},
+	{name: "Bytes Sent to Transport/sec"},
+	{name: "Checkpoint Pages/sec"},
+	{name: "CPU usage %", byInstance: true},
+	{name: "Data File(s) Size (KB)"},
+	{name: "Disk Read Bytes/sec"},
+	{name: "Disk Read IO Throttled/sec"},
+	{name: "Disk Read IO/sec"},
+	{name: "Disk Write Bytes/sec"},
+	{name: "Disk Write IO Throttled/sec"},
+	{name: "Disk Write IO/sec"},
+	{name: "Flow Control Time (ms/sec)"},
+	{name: "Flow Control/sec"},
+	{name: "Forwarded Records/sec"},
+	{name: "Free list stalls/sec"},
+	{name: "Free Space in tempdb (KB)"},
+	{name: "Full Scans/sec"},
+	{name: "Index Searches/sec"},
+	{name: "Latch Waits/sec"},
+	{name: "Lazy Writes/sec"},
+	{name: "Log Apply Pending Queue"},
+	{name: "Log Apply Ready Queue"},
+	{name: "Log Bytes Flushed/sec"},
+	{name: "Log Bytes Received/sec"},
+	{name: "Log File(s) Size (KB)"},
+	{name: "Log File(s) Used Size (KB)"},
+	{name: "Log Flush Wait Time"},
+	{name: "Log Flushes/sec"},
+	{name: "Log Send Queue"},
+	{name: "Log Send Queue KB"},
+	{name: "Logins/sec"},
+	{name: "Logouts/sec"},
+	{name: "Memory broker clerk size"},
+	{name: "Memory Grants Outstanding"},
+	{name: "Memory Grants Pending"},
+	{name: "Msg Fragment Recv Size Avg"},
+	{name: "Msg Fragment Send Size Avg"},
+	{name: "Page life expectancy"},
+	{name: "Page Lookups/sec"},
+	{name: "Page Reads/sec"},
+	{name: "Page Splits/sec"},
+	{name: "Page Writes/sec"},
+	{name: "Percent Log Used"},
+	{name: "Processes blocked"},
+	{name: "Queued requests"},
+	{name: "Readahead Pages/sec"},
+	{name: "Receive I/O Len Avg"},
+	{name: "Receives from Replica/sec"},
+	{name: "Recovery Queue"},
+	{name: "Redo Queue KB"},
+	{name: "Redone Bytes/sec"},
+	{name: "Requests completed/sec"},
+	{name: "Resent Messages/sec"},
+	{name: "Send I/O Len Avg"},
+	{name: "Sends to Replica/sec"},
+	{name: "Sends to Transport/sec"},
+	{name: "SQL Compilations/sec"},
+	{name: "SQL Re-Compilations/sec"},
+	{name: "Target Server Memory (KB)"},
+	{name: "Temp Tables Creation Rate"},
+	{name: "Temp Tables For Destruction"},
+	{name: "Total Server Memory (KB)"},
+	{name: "Transaction Delay"},
+	{name: "Transactions/sec"},
+	{name: "Update conflict ratio"},
+	{name: "Used memory (KB)"},
+	{name: "User Connections"},
+	{name: "Version Store Size (KB)"},
+	{name: "Write Transactions/sec"},
+	{name: "XTP Controller DLC Latency/Fetch"},
+	{name: "XTP Memory Used (KB)"},
+}
+
+// counterSet is the set of counters collected by a metricset, the built-in
+// counters and those included in the configuration.
+type counterSet struct {
+	// byName indexes the counters by their lower case name, the case of the
+	// counter names varies between SQL Server versions.
+	byName map[string]counter
+
+	// query selects the counters and all the base counters
+	// (PERF_LARGE_RAW_BASE) needed to compute the fractions and averages.
+	query string
+}
+
+func newCounterSet(included []counter) *counterSet {
+	set := &counterSet{byName: make(map[string]counter, len(counters)+len(included))}
+	var names []string
+	for _, list := range [][]counter{counters, included} {
+		for _, c := range list {
+			key := strings.ToLower(c.name)
+			if _, found := set.byName[key]; !found {
+				names = append(names, "'"+strings.Replace(c.name, "'", "''", -1)+"'")
+			}
+			set.byName[key] = c
+		}
+	}
+
+	set.query = fmt.Sprintf(`
+		SELECT * FROM sys.dm_os_performance_counters
+		WHERE counter_name IN (%s)
+		OR cntr_type = 1073939712
+	`, strings.Join(names, ", "))
+	return set
+}
+
+// get returns the definition of a collected counter.
+func (s *counterSet) get(name string) (counter, bool) {
+	c, found := s.byName[strings.ToLower(name)]
+	return c, found
+}
+
+// fields declares the counter fields, named by TransformFieldKey.
+var fields = func() mssql.Field {
+	group := mssql.Field{
+		Name:        "performance",
+		Type:        "group",
+		Description: "`performance` contains the counters of sys.dm_os_performance_counters.",
+	}
+	for _, c := range counters {
+		f := mssql.Field{
+			Name:        TransformFieldKey(c.name),
+			Type:        "float",
+			Description: fmt.Sprintf("Value of the %s counter.", c.name),
+		}
+		if c.byInstance {
+			f.Type = "object"
+			f.ObjectType = "float"
+			f.Description = fmt.Sprintf("Values of the %s counter, keyed by counter instance.", c.name)
+		}
+		group.Fields = append(group.Fields, f)
+	}
+	return group
+}()
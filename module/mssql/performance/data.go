@@ -0,0 +1,260 @@
+package performance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+type DmOsPerfResult struct {
+	ObjectName   string
+	CounterName  string
+	InstanceName string
+	CounterValue int64
+	CounterType  int
+
+	// ByInstance is set for the counters reported per counter instance.
+	ByInstance bool
+}
+
+type BeatResult struct {
+	EventKey   string
+	EventValue float64
+
+	// Source is the counter the value was computed from.
+	Source DmOsPerfResult
+}
+
+func QueryDmOsPerformanceCounters(ctx context.Context, q mssql.Querier, set *counterSet, deltas *mssql.Deltas) ([]BeatResult, error) {
+	countersByType := make(map[int][]DmOsPerfResult)
+	err := q.Query(ctx, set.query, func(rows mssql.Rows) error {
+		result := DmOsPerfResult{}
+		err := rows.Scan(&result.ObjectName,
+			&result.CounterName,
+			&result.InstanceName,
+			&result.CounterValue,
+			&result.CounterType)
+		if err != nil {
+			return err
+		}
+
+		result.ObjectName = strings.TrimSpace(result.ObjectName)
+		result.CounterName = strings.TrimSpace(result.CounterName)
+		result.InstanceName = strings.TrimSpace(result.InstanceName)
+		if c, found := set.get(result.CounterName); found {
+			result.ByInstance = c.byInstance
+		}
+		countersByType[result.CounterType] = append(countersByType[result.CounterType], result)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	keepTotals(countersByType)
+
+	beatResults, err := CalculateCounters(countersByType, deltas)
+	if err != nil {
+		return nil, err
+	}
+	deltas.Next()
+
+	return beatResults, nil
+}
+
+// keepTotals drops the instances of the counters not reported per instance
+// when the counter has a _Total instance, such as the Percent Log Used of
+// every database. Their values would otherwise overwrite each other.
+func keepTotals(countersByType map[int][]DmOsPerfResult) {
+	totals := map[string]bool{}
+	for ctype, results := range countersByType {
+		for _, result := range results {
+			if ctype != typeRawBase && !result.ByInstance && result.InstanceName == "_Total" {
+				totals[result.ObjectName+"|"+result.CounterName] = true
+			}
+		}
+	}
+
+	for ctype, results := range countersByType {
+		kept := results[:0]
+		for _, result := range results {
+			if !totals[result.ObjectName+"|"+result.CounterName] || result.InstanceName == "_Total" {
+				kept = append(kept, result)
+			}
+		}
+		countersByType[ctype] = kept
+	}
+}
+
+// CalculateCounters computes the values of the counters according to their
+// type. Averages are computed over the interval since the previous fetch
+// recorded in deltas, they are only available from the second fetch.
+func CalculateCounters(countersByType map[int][]DmOsPerfResult, deltas *mssql.Deltas) ([]BeatResult, error) {
+	beatResults := make([]BeatResult, 0)
+	for ctype, results := range countersByType {
+		for _, result := range results {
+			var beatResult BeatResult
+			var err error
+			if ctype == typeRawBase {
+				continue //PERF_LARGE_RAW_BASE is used in PERF_LARGE_RAW_FRACTION
+			}
+			switch ctype {
+			case typeRawFraction:
+				baseResults := countersByType[typeRawBase]
+				beatResult, err = CalculatePerfLargeRawFraction(&result, &baseResults)
+			case typeBulkCount:
+				beatResult, err = CalculatePerfCounterBulkCount(&result)
+			case typeAverageBulk:
+				baseResults := countersByType[typeRawBase]
+				beatResult, err = CalculatePerfAverageBulk(&result, &baseResults, deltas)
+			case typeLargeRawcount:
+				beatResult, err = CalculatePerfCounterLargeRawcount(&result)
+			default:
+				return nil, errors.New(fmt.Sprintf("Unknown counter type: %d", ctype))
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			if beatResult != (BeatResult{}) { // Skip empty results
+				beatResult.Source = result
+				beatResults = append(beatResults, beatResult)
+			}
+		}
+	}
+
+	return beatResults, nil
+}
+
+func CalculatePerfCounterLargeRawcount(result *DmOsPerfResult) (BeatResult, error) {
+	e := BeatResult{
+		EventKey:   GetDmOsPerfFieldKey(result),
+		EventValue: float64(result.CounterValue),
+	}
+
+	return e, nil
+}
+
+func CalculatePerfCounterBulkCount(result *DmOsPerfResult) (BeatResult, error) {
+	e := BeatResult{
+		EventKey:   GetDmOsPerfFieldKey(result),
+		EventValue: float64(result.CounterValue),
+	}
+
+	return e, nil
+}
+
+func CalculatePerfLargeRawFraction(result *DmOsPerfResult, baseResults *[]DmOsPerfResult) (BeatResult, error) {
+	var base DmOsPerfResult
+	for _, baseResult := range *baseResults {
+		if baseResult.CounterName == fmt.Sprintf("%s base", result.CounterName) && baseResult.InstanceName == result.InstanceName {
+			base = baseResult
+		}
+	}
+
+	if base == (DmOsPerfResult{}) {
+		return BeatResult{}, errors.New(fmt.Sprintf("Base Counter not found for %s: %s", result.CounterName, fmt.Sprintf("%s base", result.CounterName)))
+	}
+
+	// The base of an idle resource pool is 0.
+	var perfValue float64
+	if base.CounterValue != 0 {
+		perfValue = 100 * float64(result.CounterValue) / float64(base.CounterValue)
+	}
+	e := BeatResult{
+		EventKey:   GetDmOsPerfFieldKey(result),
+		EventValue: perfValue,
+	}
+
+	return e, nil
+}
+
+// CalculatePerfAverageBulk computes an average over the interval, the
+// increase of the counter divided by the increase of its base. The base of
+// "Average Wait Time (ms)" is "Average Wait Time Base", that of
+// "Avg Disk Read IO (ms)" is "Avg Disk Read IO (ms) Base".
+func CalculatePerfAverageBulk(result *DmOsPerfResult, baseResults *[]DmOsPerfResult, deltas *mssql.Deltas) (BeatResult, error) {
+	r, _ := regexp.Compile("\\s\\((.*)\\)$") // Remove (ms) and such from end of Counter Name to find base
+	baseNames := []string{
+		strings.ToLower(fmt.Sprintf("%s Base", result.CounterName)),
+		strings.ToLower(fmt.Sprintf("%s Base", r.ReplaceAllString(result.CounterName, ""))),
+	}
+
+	// Find base value
+	var base DmOsPerfResult
+	for _, baseResult := range *baseResults {
+		name := strings.ToLower(baseResult.CounterName)
+		if (name == baseNames[0] || name == baseNames[1]) && baseResult.InstanceName == result.InstanceName {
+			base = baseResult
+		}
+	}
+
+	if base == (DmOsPerfResult{}) {
+		logp.Warn("Base Counter not found for %s: %s", result.CounterName, baseNames[1])
+		return BeatResult{}, nil
+	}
+
+	// Only available after the first loop, as we need reference values
+	key := result.ObjectName + "|" + result.CounterName + "|" + result.InstanceName
+	delta, ok := deltas.Delta(key, result.CounterValue, base.CounterValue)
+	if !ok {
+		return BeatResult{}, nil
+	}
+
+	var quotient float64 = 0
+	if delta[1] != 0 {
+		quotient = float64(delta[0]) / float64(delta[1])
+	}
+	e := BeatResult{
+		EventKey:   GetDmOsPerfFieldKey(result),
+		EventValue: quotient,
+	}
+	return e, nil
+}
+
+func GenerateEvent(beatResults *[]BeatResult) (common.MapStr, error) {
+	fields := common.MapStr{}
+	for _, beatResult := range *beatResults {
+		_, err := fields.Put(beatResult.EventKey, beatResult.EventValue)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+// GetDmOsPerfFieldKey returns the event key of a counter. Counters reported
+// per instance are keyed by the counter and the instance name.
+func GetDmOsPerfFieldKey(result *DmOsPerfResult) string {
+	key := TransformFieldKey(result.CounterName)
+	if result.ByInstance {
+		instance := result.InstanceName
+		if instance == "" {
+			instance = "default"
+		}
+		key += "." + TransformFieldKey(instance)
+	}
+
+	return key
+}
+
+// TransformFieldKey turns a counter or instance name into a field name:
+// lower case words separated by underscores, with % spelled pct.
+func TransformFieldKey(key string) string {
+	key = strings.Replace(key, "%", "pct", -1)
+	r, _ := regexp.Compile("[.()]")
+	key = r.ReplaceAllString(key, "")
+	r, _ = regexp.Compile("[^A-Za-z0-9]+")
+	key = r.ReplaceAllString(key, "_")
+
+	return strings.ToLower(strings.Trim(key, "_"))
+}
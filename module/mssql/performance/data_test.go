@@ -0,0 +1,75 @@
+// +build !integration
+
+package performance
+
+import (
+	"testing"
+)
+
+func TestKeepTotals(t *testing.T) {
+	countersByType := map[int][]DmOsPerfResult{
+		typeLargeRawcount: {
+			{ObjectName: "SQLServer:Databases", CounterName: "Percent Log Used", InstanceName: "master", CounterValue: 38},
+			{ObjectName: "SQLServer:Databases", CounterName: "Percent Log Used", InstanceName: "_Total", CounterValue: 12},
+			{ObjectName: "SQLServer:Databases", CounterName: "Percent Log Used", InstanceName: "sales", CounterValue: 70},
+			{ObjectName: "SQLServer:Memory Broker Clerks", CounterName: "Memory broker clerk size", InstanceName: "Buffer Pool", CounterValue: 10},
+			{ObjectName: "SQLServer:Resource Pool Stats", CounterName: "Used memory (KB)", InstanceName: "_Total", CounterValue: 300, ByInstance: true},
+			{ObjectName: "SQLServer:Resource Pool Stats", CounterName: "Used memory (KB)", InstanceName: "default", CounterValue: 200, ByInstance: true},
+		},
+	}
+	keepTotals(countersByType)
+
+	results, err := CalculateCounters(countersByType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := GenerateEvent(&results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{
+		"percent_log_used":         12,
+		"memory_broker_clerk_size": 10,
+		"used_memory_kb.total":     300,
+		"used_memory_kb.default":   200,
+	}
+	for key, value := range expected {
+		if v, _ := fields.GetValue(key); v != value {
+			t.Errorf("expected %s %v, got %v", key, value, v)
+		}
+	}
+}
+
+func TestCalculatePerfLargeRawFraction(t *testing.T) {
+	tests := []struct {
+		name        string
+		value, base int64
+		expected    float64
+	}{
+		{"idle resource pool", 0, 0, 0},
+		{"busy resource pool", 62, 124, 50},
+		{"non-integral ratio", 1, 3, 100.0 / 3},
+		{"ratio below 1%", 41, 8100, 41 * 100.0 / 8100},
+	}
+	for _, test := range tests {
+		result := DmOsPerfResult{ObjectName: "SQLServer:Resource Pool Stats", CounterName: "CPU usage %", InstanceName: "default", CounterValue: test.value}
+		bases := []DmOsPerfResult{
+			{ObjectName: "SQLServer:Resource Pool Stats", CounterName: "CPU usage % base", InstanceName: "default", CounterValue: test.base},
+		}
+		r, err := CalculatePerfLargeRawFraction(&result, &bases)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if r.EventValue != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, r.EventValue)
+		}
+	}
+
+	result := DmOsPerfResult{ObjectName: "SQLServer:Resource Pool Stats", CounterName: "CPU usage %", InstanceName: "default", CounterValue: 1}
+	bases := []DmOsPerfResult{
+		{ObjectName: "SQLServer:Resource Pool Stats", CounterName: "CPU usage % base", InstanceName: "internal", CounterValue: 3},
+	}
+	if _, err := CalculatePerfLargeRawFraction(&result, &bases); err == nil {
+		t.Error("expected an error without the base of the instance")
+	}
+}
@@ -0,0 +1,128 @@
+package performance
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+// Counter types of sys.dm_os_performance_counters.
+const (
+	typeLargeRawcount = 65792      // PERF_COUNTER_LARGE_RAWCOUNT
+	typeBulkCount     = 272696576  // PERF_COUNTER_BULK_COUNT
+	typeRawFraction   = 537003264  // PERF_LARGE_RAW_FRACTION
+	typeAverageBulk   = 1073874176 // PERF_AVERAGE_BULK
+	typeRawBase       = 1073939712 // PERF_LARGE_RAW_BASE
+)
+
+var counterTypes = map[int]struct{ name, computation string }{
+	typeLargeRawcount: {"PERF_COUNTER_LARGE_RAWCOUNT", "current value"},
+	typeBulkCount:     {"PERF_COUNTER_BULK_COUNT", "cumulative value"},
+	typeRawFraction:   {"PERF_LARGE_RAW_FRACTION", "100 * value / base"},
+	typeAverageBulk:   {"PERF_AVERAGE_BULK", "value delta / base delta over the period"},
+	typeRawBase:       {"PERF_LARGE_RAW_BASE", "base of another counter, not reported"},
+}
+
+const listQuery = `
+	SELECT RTRIM(object_name), RTRIM(counter_name), RTRIM(instance_name), cntr_type
+	FROM sys.dm_os_performance_counters
+	ORDER BY object_name, counter_name, instance_name
+`
+
+// CounterInfo describes a counter exposed by the server.
+type CounterInfo struct {
+	Object    string
+	Name      string
+	Type      int
+	Instances []string
+
+	// Included is set when the metricset collects the counter, ByInstance
+	// when it reports it per instance.
+	Included   bool
+	ByInstance bool
+}
+
+// TypeName returns the name of the counter type.
+func (c CounterInfo) TypeName() string {
+	if t, found := counterTypes[c.Type]; found {
+		return t.name
+	}
+	return "unknown"
+}
+
+// Computation describes how the metricset computes the value of the counter.
+func (c CounterInfo) Computation() string {
+	if t, found := counterTypes[c.Type]; found {
+		return t.computation
+	}
+	return "not supported"
+}
+
+// Reported tells whether the metricset can report the counter. Base counters
+// are only used to compute other counters.
+func (c CounterInfo) Reported() bool {
+	_, found := counterTypes[c.Type]
+	return found && c.Type != typeRawBase
+}
+
+// Field returns the name of the field of the counter in the events.
+func (c CounterInfo) Field() string {
+	if c.ByInstance {
+		return TransformFieldKey(c.Name) + ".<instance>"
+	}
+	return TransformFieldKey(c.Name)
+}
+
+// ListCounters returns all the counters exposed by the server, ordered by
+// object and name. The counters collected by ms are marked as included, ms
+// can be nil.
+func ListCounters(ctx context.Context, q mssql.Querier, ms *MetricSet) ([]CounterInfo, error) {
+	var infos []CounterInfo
+	err := q.Query(ctx, listQuery, func(rows mssql.Rows) error {
+		var object, name, instance string
+		var ctype int
+		if err := rows.Scan(&object, &name, &instance, &ctype); err != nil {
+			return err
+		}
+
+		n := len(infos)
+		if n == 0 || infos[n-1].Object != object || infos[n-1].Name != name || infos[n-1].Type != ctype {
+			info := CounterInfo{Object: object, Name: name, Type: ctype}
+			if ms != nil && info.Reported() {
+				var c counter
+				c, info.Included = ms.counters.get(name)
+				info.ByInstance = c.byInstance
+			}
+			infos = append(infos, info)
+			n++
+		}
+		if instance != "" {
+			infos[n-1].Instances = append(infos[n-1].Instances, instance)
+		}
+		return nil
+	})
+	return infos, err
+}
+
+// IncludeConfig returns the performance.counters.include configuration
+// collecting the counters. Counters with several instances are reported per
+// instance.
+func IncludeConfig(infos []CounterInfo) string {
+	var b strings.Builder
+	b.WriteString("performance.counters.include:\n")
+	seen := map[string]bool{}
+	for _, c := range infos {
+		key := strings.ToLower(c.Name)
+		if !c.Reported() || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		b.WriteString("  - name: \"" + strings.Replace(c.Name, `"`, `\"`, -1) + "\"\n")
+		if len(c.Instances) > 1 {
+			b.WriteString("    by_instance: true\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+// +build !integration
+
+package performance
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIncludeConfig(t *testing.T) {
+	infos := []CounterInfo{
+		{Object: "SQLServer:Locks", Name: "Lock Waits/sec", Type: typeBulkCount, Instances: []string{"_Total", "Database", "Object"}},
+		{Object: "SQLServer:Locks", Name: "Average Wait Time Base", Type: typeRawBase, Instances: []string{"_Total"}},
+		{Object: "SQLServer:General Statistics", Name: "Mars Deadlocks", Type: typeLargeRawcount},
+		{Object: "MSSQL$NAMED:General Statistics", Name: "Mars Deadlocks", Type: typeLargeRawcount},
+	}
+
+	expected := `performance.counters.include:
+  - name: "Lock Waits/sec"
+    by_instance: true
+  - name: "Mars Deadlocks"
+`
+	if got := IncludeConfig(infos); got != expected {
+		t.Errorf("unexpected config:\n%s", got)
+	}
+}
+
+func TestCounterSetIncludes(t *testing.T) {
+	set := newCounterSet([]counter{
+		{name: "Lock Waits/sec", byInstance: true},
+		{name: "Batch Requests/sec"},
+		{name: "It's quoted"},
+	})
+
+	if c, found := set.get("LOCK WAITS/SEC"); !found || !c.byInstance {
+		t.Errorf("included counter not found by instance: %+v", c)
+	}
+	if _, found := set.get("Page life expectancy"); !found {
+		t.Error("built-in counter not found")
+	}
+	if strings.Count(set.query, "'Batch Requests/sec'") != 1 {
+		t.Error("built-in counter included twice in the query")
+	}
+	if !strings.Contains(set.query, "'It''s quoted'") {
+		t.Error("counter name not escaped in the query")
+	}
+}
@@ -0,0 +1,86 @@
+package performance
+
+import (
+	"context"
+
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "performance", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("performance", fields)
+	mssql.RequirePermissions("performance", mssql.ViewServerState)
+}
+
+// MetricSet collects the counters exposed by sys.dm_os_performance_counters.
+type MetricSet struct {
+	*mssql.MetricSet
+
+	counters *counterSet
+
+	// Counters of the previous fetch, needed to compute averages over the
+	// interval.
+	deltas *mssql.Deltas
+}
+
+// includeConfig is a counter collected in addition to the built-in ones.
+type includeConfig struct {
+	Name       string `config:"name" validate:"required"`
+	ByInstance bool   `config:"by_instance"`
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Include []includeConfig `config:"performance.counters.include"`
+	}{}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	included := make([]counter, len(config.Include))
+	for i, c := range config.Include {
+		included[i] = counter{name: c.Name, byInstance: c.ByInstance}
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, counters: newCounterSet(included), deltas: mssql.NewDeltas()}, nil
+}
+
+// Fetch queries the performance counters and reports them as a single event.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+// NeedsBaseline is true until the counters needed to compute the averages were
+// fetched once.
+func (m *MetricSet) NeedsBaseline() bool {
+	return !m.deltas.Baseline()
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	beatResults, err := QueryDmOsPerformanceCounters(ctx, m.MetricSet, m.counters, m.deltas)
+	if err != nil {
+		return err
+	}
+
+	fields, err := GenerateEvent(&beatResults)
+	if err != nil {
+		return err
+	}
+
+	if m.PrometheusEnabled() {
+		m.Publish(samples(beatResults))
+	}
+
+	r.Event(mb.Event{MetricSetFields: fields})
+	return nil
+}
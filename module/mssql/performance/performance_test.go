@@ -0,0 +1,90 @@
+// +build !integration
+
+package performance
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/module/mssql/mtest"
+)
+
+// sampleCounters returns one row for every collected counter, with the base
+// counters needed to compute them. Counters reported per instance are
+// fractions with two instances, the others raw counts, except one average.
+func sampleCounters(offset int64) map[int][]DmOsPerfResult {
+	suffix := regexp.MustCompile(`\s\((.*)\)$`)
+	byType := map[int][]DmOsPerfResult{}
+	add := func(r DmOsPerfResult) {
+		byType[r.CounterType] = append(byType[r.CounterType], r)
+	}
+
+	for _, c := range counters {
+		switch {
+		case c.byInstance:
+			for _, instance := range []string{"default", "Pool 1"} {
+				add(DmOsPerfResult{CounterName: c.name, InstanceName: instance, CounterValue: 50 + offset, CounterType: 537003264, ByInstance: true})
+				add(DmOsPerfResult{CounterName: c.name + " base", InstanceName: instance, CounterValue: 100 + offset, CounterType: 1073939712})
+			}
+		case c.name == "Average Latch Wait Time (ms)":
+			base := suffix.ReplaceAllString(c.name, "") + " Base"
+			add(DmOsPerfResult{CounterName: c.name, CounterValue: 1000 + 10*offset, CounterType: 1073874176})
+			add(DmOsPerfResult{CounterName: base, CounterValue: 100 + offset, CounterType: 1073939712})
+		default:
+			add(DmOsPerfResult{CounterName: c.name, CounterValue: 10 + offset, CounterType: 65792})
+		}
+	}
+	return byType
+}
+
+// TestCountersDeclared checks the fields of every counter, the recordings
+// only contain some of them.
+func TestCountersDeclared(t *testing.T) {
+	deltas := mssql.NewDeltas()
+	if _, err := CalculateCounters(sampleCounters(0), deltas); err != nil {
+		t.Fatal(err)
+	}
+	deltas.Next()
+	results, err := CalculateCounters(sampleCounters(5), deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := GenerateEvent(&results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(counters)+countByInstance() {
+		t.Errorf("expected a value for every counter and instance, got %d", len(results))
+	}
+
+	mtest.CheckEventFields(t, "performance", mb.Event{MetricSetFields: fields})
+}
+
+func countByInstance() int {
+	n := 0
+	for _, c := range counters {
+		if c.byInstance {
+			n++
+		}
+	}
+	return n
+}
+
+func TestTransformFieldKey(t *testing.T) {
+	for name, expected := range map[string]string{
+		"CPU usage %":                "cpu_usage_pct",
+		"Log File(s) Size (KB)":      "log_files_size_kb",
+		"Avg. microsec/Read Comp":    "avg_microsec_read_comp",
+		"SQL Re-Compilations/sec":    "sql_re_compilations_sec",
+		"Flow Control Time (ms/sec)": "flow_control_time_ms_sec",
+		"Receive I/O Len Avg":        "receive_i_o_len_avg",
+	} {
+		if key := TransformFieldKey(name); key != expected {
+			t.Errorf("TransformFieldKey(%q) = %q, expected %q", name, key, expected)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package performance
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mathenning/mssqlbeat/prometheus"
+)
+
+// samples turns the counter values into Prometheus samples. Cumulative
+// counters (PERF_COUNTER_BULK_COUNT) are exposed as counters without the
+// per second unit of their name, so rates are computed by Prometheus. The
+// other values are gauges.
+func samples(beatResults []BeatResult) []prometheus.Sample {
+	samples := make([]prometheus.Sample, 0, len(beatResults))
+	for _, r := range beatResults {
+		c := r.Source
+		s := prometheus.Sample{
+			Name:   "mssql_" + TransformFieldKey(c.CounterName),
+			Help:   fmt.Sprintf("Value of the %s counter.", c.CounterName),
+			Type:   prometheus.Gauge,
+			Labels: map[string]string{},
+			Value:  r.EventValue,
+		}
+		if c.CounterType == typeBulkCount {
+			s.Name = strings.TrimSuffix(s.Name, "_sec")
+			s.Type = prometheus.Counter
+			s.Help = fmt.Sprintf("Cumulative value of the %s counter.", c.CounterName)
+		}
+
+		// The object name is prefixed with the instance name, as in
+		// SQLServer:Buffer Manager or MSSQL$NAMED:Buffer Manager.
+		object := c.ObjectName
+		if i := strings.Index(object, ":"); i >= 0 {
+			object = object[i+1:]
+		}
+		s.Labels["object"] = object
+		if c.InstanceName != "" {
+			if object == "Databases" {
+				s.Labels["database"] = c.InstanceName
+			} else {
+				s.Labels["counter_instance"] = c.InstanceName
+			}
+		}
+		samples = append(samples, s)
+	}
+	return samples
+}
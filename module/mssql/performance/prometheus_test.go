@@ -0,0 +1,36 @@
+// +build !integration
+
+package performance
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mathenning/mssqlbeat/prometheus"
+)
+
+func TestSamples(t *testing.T) {
+	results := []BeatResult{
+		{EventKey: "batch_requests_sec", EventValue: 1200, Source: DmOsPerfResult{
+			ObjectName: "SQLServer:SQL Statistics", CounterName: "Batch Requests/sec", CounterType: 272696576,
+		}},
+		{EventKey: "percent_log_used", EventValue: 12, Source: DmOsPerfResult{
+			ObjectName: "MSSQL$NAMED:Databases", CounterName: "Percent Log Used", InstanceName: "sales", CounterType: 65792,
+		}},
+		{EventKey: "cpu_usage_pct.default", EventValue: 3, Source: DmOsPerfResult{
+			ObjectName: "SQLServer:Resource Pool Stats", CounterName: "CPU usage %", InstanceName: "default", CounterType: 537003264,
+		}},
+	}
+
+	expected := []prometheus.Sample{
+		{Name: "mssql_batch_requests", Help: "Cumulative value of the Batch Requests/sec counter.", Type: prometheus.Counter,
+			Labels: map[string]string{"object": "SQL Statistics"}, Value: 1200},
+		{Name: "mssql_percent_log_used", Help: "Value of the Percent Log Used counter.", Type: prometheus.Gauge,
+			Labels: map[string]string{"object": "Databases", "database": "sales"}, Value: 12},
+		{Name: "mssql_cpu_usage_pct", Help: "Value of the CPU usage % counter.", Type: prometheus.Gauge,
+			Labels: map[string]string{"object": "Resource Pool Stats", "counter_instance": "default"}, Value: 3},
+	}
+	if got := samples(results); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected samples:\n%+v\nexpected:\n%+v", got, expected)
+	}
+}
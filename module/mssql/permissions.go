@@ -0,0 +1,181 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	mssqldb "github.com/denisenkom/go-mssqldb"
+)
+
+// Permission is a permission the login needs for a metricset to collect its
+// data. Query returns 1 when the login has the permission. Grant is the T-SQL
+// granting it, run in master with the quoted login name in the @quoted
+// variable and the login name as a string literal in @literal.
+type Permission struct {
+	Name  string
+	Query string
+	Grant string
+}
+
+// Permissions required by the metricsets.
+var (
+	ViewServerState = Permission{
+		Name:  "VIEW SERVER STATE",
+		Query: "SELECT HAS_PERMS_BY_NAME(NULL, NULL, 'VIEW SERVER STATE')",
+		Grant: "EXEC (N'GRANT VIEW SERVER STATE TO ' + @quoted);",
+	}
+	ViewAnyDefinition = Permission{
+		Name:  "VIEW ANY DEFINITION",
+		Query: "SELECT HAS_PERMS_BY_NAME(NULL, NULL, 'VIEW ANY DEFINITION')",
+		Grant: "EXEC (N'GRANT VIEW ANY DEFINITION TO ' + @quoted);",
+	}
+	// DatabaseAccess is needed to read the catalog views of the user
+	// databases. The system databases are accessible through their guest
+	// user. Read-only databases are left out, a user cannot be created in
+	// them.
+	DatabaseAccess = Permission{
+		Name:  "access to every user database",
+		Query: databaseAccessQuery,
+		Grant: `DECLARE @users nvarchar(max) = N'';
+SELECT @users += N'USE ' + QUOTENAME(name) + N';
+IF USER_ID(' + @literal + N') IS NULL CREATE USER ' + @quoted + N' FOR LOGIN ' + @quoted + N';
+'
+FROM sys.databases
+WHERE database_id > 4 AND state = 0 AND is_read_only = 0;
+EXEC (@users);`,
+	}
+	// DatabaseReader is needed to read the statistics of the tables of the
+	// user databases. The membership of db_datareader cannot be checked from
+	// master, only the access to the databases is.
+	DatabaseReader = Permission{
+		Name:  "db_datareader in every user database",
+		Query: databaseAccessQuery,
+		Grant: `DECLARE @readers nvarchar(max) = N'';
+SELECT @readers += N'USE ' + QUOTENAME(name) + N';
+IF USER_ID(' + @literal + N') IS NULL CREATE USER ' + @quoted + N' FOR LOGIN ' + @quoted + N';
+ALTER ROLE db_datareader ADD MEMBER ' + @quoted + N';
+'
+FROM sys.databases
+WHERE database_id > 4 AND state = 0 AND is_read_only = 0;
+EXEC (@readers);`,
+	}
+)
+
+// databaseAccessQuery returns 1 when the login can access every online user
+// database it can be given a user in.
+const databaseAccessQuery = "SELECT CASE WHEN EXISTS (SELECT 1 FROM sys.databases WHERE database_id > 4 AND state = 0 AND is_read_only = 0 AND HAS_DBACCESS(name) = 0) THEN 0 ELSE 1 END"
+
+var (
+	permissionsMu sync.RWMutex
+	permissions   = map[string][]Permission{}
+)
+
+// RequirePermissions declares the permissions needed by a metricset. It is
+// called from the init function of the metricset, next to its registration.
+func RequirePermissions(metricset string, required ...Permission) {
+	permissionsMu.Lock()
+	defer permissionsMu.Unlock()
+
+	permissions[metricset] = append(permissions[metricset], required...)
+}
+
+// RequiredPermissions returns the permissions needed by a metricset.
+func RequiredPermissions(metricset string) []Permission {
+	permissionsMu.RLock()
+	defer permissionsMu.RUnlock()
+
+	return permissions[metricset]
+}
+
+// PermissionMetricSets returns the names of the metricsets that declared
+// permissions, sorted.
+func PermissionMetricSets() []string {
+	permissionsMu.RLock()
+	defer permissionsMu.RUnlock()
+
+	names := make([]string, 0, len(permissions))
+	for name := range permissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasPermission tells whether the login has the permission.
+func HasPermission(ctx context.Context, q Querier, p Permission) (bool, error) {
+	var granted sql.NullInt64
+	if err := QueryRow(ctx, q, p.Query, &granted); err != nil {
+		return false, err
+	}
+	return granted.Valid && granted.Int64 == 1, nil
+}
+
+// MissingPermissions returns the names of the permissions the login lacks.
+func MissingPermissions(ctx context.Context, q Querier, required []Permission) ([]string, error) {
+	var missing []string
+	for _, p := range required {
+		granted, err := HasPermission(ctx, q, p)
+		if err != nil {
+			return nil, err
+		}
+		if !granted {
+			missing = append(missing, p.Name)
+		}
+	}
+	return missing, nil
+}
+
+// permissionDeniedErrors are the server errors raised when the login lacks a
+// permission.
+var permissionDeniedErrors = map[int32]bool{
+	229: true, // The permission was denied on the object
+	297: true, // The user does not have permission to perform this action
+	300: true, // The permission was denied on the object, server or database
+	916: true, // The server principal is not able to access the database
+}
+
+// IsPermissionDenied tells whether the error was raised because the login
+// lacks a permission.
+func IsPermissionDenied(err error) bool {
+	e, ok := err.(mssqldb.Error)
+	return ok && permissionDeniedErrors[e.Number]
+}
+
+// GrantScript returns the T-SQL creating the login with the permissions, and
+// nothing more. SQL logins are created with the password given to sqlcmd in
+// the password variable, Windows logins from the Windows account.
+func GrantScript(login string, windows bool, metricsets []string, required []Permission) string {
+	var b strings.Builder
+	b.WriteString("-- Least-privilege login for mssqlbeat, with the permissions needed by the\n")
+	fmt.Fprintf(&b, "-- metricsets %s.\n", strings.Join(metricsets, ", "))
+	if !windows {
+		b.WriteString("-- Run it with sqlcmd to set the password of the login:\n")
+		b.WriteString("--   sqlcmd -S <server> -i <this file> -v password=\"<password>\"\n")
+	}
+	b.WriteString("USE master;\n\n")
+
+	fmt.Fprintf(&b, "DECLARE @login sysname = N'%s';\n", strings.Replace(login, "'", "''", -1))
+	b.WriteString("DECLARE @quoted nvarchar(300) = QUOTENAME(@login);\n")
+	b.WriteString("DECLARE @literal nvarchar(600) = N'N''' + REPLACE(@login, '''', '''''') + N'''';\n\n")
+
+	b.WriteString("IF SUSER_ID(@login) IS NULL\n")
+	if windows {
+		b.WriteString("\tEXEC (N'CREATE LOGIN ' + @quoted + N' FROM WINDOWS');\n")
+	} else {
+		b.WriteString("\tEXEC (N'CREATE LOGIN ' + @quoted + N' WITH PASSWORD = N''$(password)'', CHECK_POLICY = ON');\n")
+	}
+
+	seen := map[string]bool{}
+	for _, p := range required {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		fmt.Fprintf(&b, "\n-- %s\n%s\n", p.Name, p.Grant)
+	}
+	return b.String()
+}
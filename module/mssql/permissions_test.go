@@ -0,0 +1,41 @@
+// +build !integration
+
+package mssql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGrantScript(t *testing.T) {
+	script := GrantScript("it's", false, []string{"performance", "waits"},
+		[]Permission{ViewServerState, ViewServerState, ViewAnyDefinition})
+
+	if !strings.Contains(script, "DECLARE @login sysname = N'it''s';") {
+		t.Error("login name not escaped")
+	}
+	if strings.Count(script, ViewServerState.Grant) != 1 {
+		t.Error("permission required by two metricsets granted twice")
+	}
+	if !strings.Contains(script, ViewAnyDefinition.Grant) {
+		t.Error("VIEW ANY DEFINITION not granted")
+	}
+	if !strings.Contains(script, "$(password)") {
+		t.Error("SQL login created without the password variable")
+	}
+
+	script = GrantScript("beat", false, []string{"statistics"},
+		[]Permission{ViewAnyDefinition, DatabaseAccess, DatabaseReader})
+	if !strings.Contains(script, "CREATE USER ' + @quoted + N' FOR LOGIN ' + @quoted") ||
+		!strings.Contains(script, "FROM sys.databases\nWHERE database_id > 4") {
+		t.Errorf("expected a user created in every user database:\n%s", script)
+	}
+	if !strings.Contains(script, "ALTER ROLE db_datareader ADD MEMBER") {
+		t.Errorf("expected the login to be added to db_datareader:\n%s", script)
+	}
+
+	script = GrantScript(`DOMAIN\beat`, true, nil, nil)
+	if !strings.Contains(script, "FROM WINDOWS") || strings.Contains(script, "$(password)") {
+		t.Errorf("unexpected Windows login script:\n%s", script)
+	}
+}
@@ -0,0 +1,152 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// Rows is the row of a query result passed to a RowScanner.
+type Rows interface {
+	Scan(dest ...interface{}) error
+}
+
+// RowScanner is called for every row of a query result.
+type RowScanner func(rows Rows) error
+
+// Querier runs the queries of the metricsets. The metricsets query SQL Server
+// through their MetricSet, which uses a DBQuerier unless another Querier is
+// set, like the ReplayQuerier of the tests.
+type Querier interface {
+	// Query runs a query and calls scan for every row of the result.
+	Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error
+}
+
+// Connector is implemented by the queriers that open dedicated connections,
+// for the checks measuring the login itself.
+type Connector interface {
+	// Connect logs in to the host on a new connection, never taken from a
+	// connection pool.
+	Connect(ctx context.Context, host mb.HostData) (Conn, error)
+}
+
+// Conn is a connection opened by a Connector. Its queries run on the
+// connection until it is closed.
+type Conn interface {
+	Querier
+
+	// LoginDuration returns the duration of the connection and login.
+	LoginDuration() time.Duration
+	// Ping runs pingQuery and returns its round trip duration.
+	Ping(ctx context.Context) (time.Duration, error)
+	Close() error
+}
+
+// loginQuery stands for the login of a Conn in the recordings.
+const loginQuery = "-- login"
+
+// pingQuery is the query of Conn.Ping.
+const pingQuery = "SELECT 1"
+
+// DBQuerier runs the queries on a connection pool.
+type DBQuerier struct {
+	DB *sql.DB
+}
+
+// Query implements Querier.
+func (q DBQuerier) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	return queryRows(ctx, q.DB, query, scan, args...)
+}
+
+// Connect implements Connector.
+func (q DBQuerier) Connect(ctx context.Context, host mb.HostData) (Conn, error) {
+	return connect(ctx, host)
+}
+
+// queryer is implemented by both connection pools and connections.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func queryRows(ctx context.Context, db queryer, query string, scan RowScanner, args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// dbConn is a connection of a pool of its own, so that it is never reused
+// and each connect logs in.
+type dbConn struct {
+	db    *sql.DB
+	conn  *sql.Conn
+	login time.Duration
+}
+
+func connect(ctx context.Context, host mb.HostData) (*dbConn, error) {
+	db, err := NewConnection(host)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &dbConn{db: db, conn: conn, login: time.Since(start)}, nil
+}
+
+func (c *dbConn) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	return queryRows(ctx, c.conn, query, scan, args...)
+}
+
+func (c *dbConn) LoginDuration() time.Duration {
+	return c.login
+}
+
+func (c *dbConn) Ping(ctx context.Context) (time.Duration, error) {
+	return ping(ctx, c)
+}
+
+func (c *dbConn) Close() error {
+	c.conn.Close()
+	return c.db.Close()
+}
+
+// ping measures the round trip of pingQuery.
+func ping(ctx context.Context, q Querier) (time.Duration, error) {
+	start := time.Now()
+	var one int
+	err := QueryRow(ctx, q, pingQuery, &one)
+	return time.Since(start), err
+}
+
+// QueryRow runs a query returning a single row and scans it into dest. It
+// returns sql.ErrNoRows when the query returns no row.
+func QueryRow(ctx context.Context, q Querier, query string, dest ...interface{}) error {
+	found := false
+	err := q.Query(ctx, query, func(rows Rows) error {
+		if found {
+			return nil
+		}
+		found = true
+		return rows.Scan(dest...)
+	})
+	if err == nil && !found {
+		err = sql.ErrNoRows
+	}
+	return err
+}
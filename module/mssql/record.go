@@ -0,0 +1,244 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	mssqldb "github.com/denisenkom/go-mssqldb"
+
+	"github.com/elastic/beats/libbeat/common/file"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// RecordingConfig is the configuration of the recording of the result sets of
+// the metricsets, set in the recording namespace of the module.
+type RecordingConfig struct {
+	Enabled bool `config:"enabled"`
+	// Path is the directory of the recordings, relative to the data path.
+	Path          string `config:"path"`
+	RotateEveryKb uint   `config:"rotate_every_kb" validate:"min=1"`
+	NumberOfFiles uint   `config:"number_of_files" validate:"min=2"`
+}
+
+// DefaultRecordingConfig keeps up to 70MB of recordings per metricset.
+var DefaultRecordingConfig = RecordingConfig{
+	Path:          "recordings",
+	RotateEveryKb: 10 * 1024,
+	NumberOfFiles: 7,
+}
+
+// RecordingExtension is the extension of the recording files. The rotated
+// files get a number appended, the highest being the oldest.
+const RecordingExtension = ".ndjson"
+
+var recordingNameReplacer = strings.NewReplacer(":", "_", "/", "_", `\`, "_")
+
+// recordingName returns the name of the recording file of a metricset, from
+// its key in the stats: the host, the instance and the metricset name.
+func recordingName(key string) string {
+	return recordingNameReplacer.Replace(key) + RecordingExtension
+}
+
+var rotatedSuffix = regexp.MustCompile(`\.[0-9]+$`)
+var duplicateSuffix = regexp.MustCompile(`_[0-9]+$`)
+
+// RecordingMetricSet returns the name of the metricset that recorded a file,
+// and the name of the current file of the recording.
+func RecordingMetricSet(path string) (metricset, name string) {
+	name = rotatedSuffix.ReplaceAllString(filepath.Base(path), "")
+	key := strings.TrimSuffix(name, RecordingExtension)
+	metricset = duplicateSuffix.ReplaceAllString(key[strings.LastIndex(key, ".")+1:], "")
+	return metricset, name
+}
+
+// RecordingQuerier runs the queries on a connection pool like DBQuerier and
+// writes their result sets to a recording file, one JSON document per line.
+// The rows are scanned from the recorded values, so the events of the
+// metricset are the same when the recording is replayed.
+type RecordingQuerier struct {
+	DB *sql.DB
+
+	filename string
+	config   RecordingConfig
+
+	mu      sync.Mutex
+	rotator *file.Rotator
+}
+
+// NewRecordingQuerier creates a querier recording in the file of the
+// metricset with the given stats key. The file is opened by the first query.
+func NewRecordingQuerier(db *sql.DB, key string, config RecordingConfig) *RecordingQuerier {
+	return &RecordingQuerier{
+		DB:       db,
+		filename: filepath.Join(paths.Resolve(paths.Data, config.Path), recordingName(key)),
+		config:   config,
+	}
+}
+
+// Query implements Querier.
+func (q *RecordingQuerier) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	return q.run(ctx, q.DB, query, scan, args...)
+}
+
+// Connect implements Connector. The login is recorded as loginQuery, with
+// its duration or error.
+func (q *RecordingQuerier) Connect(ctx context.Context, host mb.HostData) (Conn, error) {
+	set := ResultSet{Time: time.Now().UTC(), Query: loginQuery, Rows: [][]interface{}{}}
+	conn, err := connect(ctx, host)
+	if err != nil {
+		set.Error = recordedError(err)
+		q.record(&set)
+		return nil, err
+	}
+	set.Duration = conn.login.Nanoseconds() / 1000
+	q.record(&set)
+	return &recordingConn{q: q, conn: conn}, nil
+}
+
+func (q *RecordingQuerier) run(ctx context.Context, db queryer, query string, scan RowScanner, args ...interface{}) error {
+	set := ResultSet{Time: time.Now().UTC(), Query: query, Rows: [][]interface{}{}}
+	for _, arg := range args {
+		set.Args = append(set.Args, recordedValue(arg))
+	}
+	start := time.Now()
+	err := q.query(ctx, db, &set, scan, args...)
+	set.Duration = time.Since(start).Nanoseconds() / 1000
+	if err != nil && set.Error == nil {
+		set.Error = recordedError(err)
+	}
+	q.record(&set)
+	return err
+}
+
+func (q *RecordingQuerier) query(ctx context.Context, db queryer, set *ResultSet, scan RowScanner, args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, set.Query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	set.Columns, err = rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(set.Columns))
+	dest := make([]interface{}, len(values))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		row := make([]interface{}, len(values))
+		for i, v := range values {
+			row[i] = recordedValue(v)
+		}
+		set.Rows = append(set.Rows, row)
+
+		if err := scan(replayRow(row)); err != nil {
+			// The row is recorded, replaying it fails the same way.
+			set.Error = &RecordedError{Message: err.Error()}
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// recordedError converts an error to its recorded form, keeping the number of
+// server errors.
+func recordedError(err error) *RecordedError {
+	if e, ok := err.(mssqldb.Error); ok {
+		return &RecordedError{Number: e.Number, Message: e.Message}
+	}
+	return &RecordedError{Message: err.Error()}
+}
+
+// recordedValue converts a value returned by the driver to its recorded form.
+func recordedValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, bool, int64, float64, string:
+		return v
+	case int:
+		return int64(v)
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (q *RecordingQuerier) record(set *ResultSet) {
+	data, err := json.Marshal(set)
+	if err != nil {
+		logp.Warn("Failed to record a result set in %s: %v", q.filename, err)
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.rotator == nil {
+		q.rotator, err = file.NewFileRotator(q.filename,
+			file.MaxSizeBytes(q.config.RotateEveryKb*1024),
+			file.MaxBackups(q.config.NumberOfFiles-1),
+			file.Permissions(0600),
+		)
+		if err != nil {
+			logp.Warn("Failed to open the recording %s: %v", q.filename, err)
+			return
+		}
+	}
+	if _, err := q.rotator.Write(append(data, '\n')); err != nil {
+		logp.Warn("Failed to record a result set in %s: %v", q.filename, err)
+	}
+}
+
+// recordingConn records the queries of a dedicated connection with those of
+// the pool.
+type recordingConn struct {
+	q    *RecordingQuerier
+	conn *dbConn
+}
+
+func (c *recordingConn) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	return c.q.run(ctx, c.conn.conn, query, scan, args...)
+}
+
+func (c *recordingConn) LoginDuration() time.Duration {
+	return c.conn.login
+}
+
+func (c *recordingConn) Ping(ctx context.Context) (time.Duration, error) {
+	return ping(ctx, c)
+}
+
+func (c *recordingConn) Close() error {
+	return c.conn.Close()
+}
+
+// Close closes the recording file.
+func (q *RecordingQuerier) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.rotator == nil {
+		return nil
+	}
+	err := q.rotator.Close()
+	q.rotator = nil
+	return err
+}
@@ -0,0 +1,387 @@
+package mssql
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mssqldb "github.com/denisenkom/go-mssqldb"
+
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// ResultSet is the recorded result of a query. Values are recorded as JSON
+// numbers, strings, booleans or null, times as RFC 3339 strings.
+type ResultSet struct {
+	// Time the query was run, not set in hand written result sets.
+	Time time.Time `json:"time"`
+
+	// Query is the recorded query, compared with the whitespace collapsed.
+	Query string `json:"query,omitempty"`
+	// Match selects the queries containing it, for hand written result
+	// sets.
+	Match string `json:"match,omitempty"`
+	// Args are the arguments of the query, such as the database of a
+	// per-database query. Result sets without arguments match any.
+	Args []interface{} `json:"args,omitempty"`
+
+	Columns []string        `json:"columns,omitempty"`
+	Rows    [][]interface{} `json:"rows"`
+	Error   *RecordedError  `json:"error,omitempty"`
+
+	// Duration of the query in microseconds, replayed as the duration of
+	// the logins and pings of a Conn.
+	Duration int64 `json:"duration_us,omitempty"`
+}
+
+// err returns the recorded error, a server error when it has a number.
+func (s *ResultSet) err() error {
+	switch {
+	case s.Error == nil:
+		return nil
+	case s.Error.Number == 0:
+		return errors.New(s.Error.Message)
+	default:
+		return mssqldb.Error{Number: s.Error.Number, Message: s.Error.Message}
+	}
+}
+
+// RecordedError is an error returned instead of a result. Server errors have
+// a number, the others are replayed with their message only.
+type RecordedError struct {
+	Number  int32  `json:"number"`
+	Message string `json:"message"`
+}
+
+// Recording is a set of recorded results, for example of a SQL Server
+// version.
+type Recording struct {
+	Server     string      `json:"server,omitempty"`
+	ResultSets []ResultSet `json:"result_sets"`
+}
+
+// LoadRecording reads recordings from JSON files, in order. A file holds
+// either a Recording, like the test fixtures, or result sets one per line,
+// like the files written by a RecordingQuerier.
+func LoadRecording(paths ...string) (*Recording, error) {
+	r := &Recording{}
+	for _, path := range paths {
+		if err := r.read(path); err != nil {
+			return nil, fmt.Errorf("failed to read recording %s: %v", path, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *Recording) read(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	for dec.More() {
+		var doc struct {
+			Recording
+			ResultSet
+		}
+		if err := dec.Decode(&doc); err != nil {
+			return err
+		}
+		if doc.ResultSets != nil {
+			if r.Server == "" {
+				r.Server = doc.Server
+			}
+			r.ResultSets = append(r.ResultSets, doc.ResultSets...)
+		} else {
+			r.ResultSets = append(r.ResultSets, doc.ResultSet)
+		}
+	}
+	return nil
+}
+
+// ReplayQuerier answers the queries with recorded results. When several
+// result sets match a query, they are returned in turn for its successive
+// runs, the last one being repeated. Result sets recorded with arguments only
+// answer the runs with the same arguments, and are returned in turn for the
+// successive runs with these arguments.
+type ReplayQuerier struct {
+	mu   sync.Mutex
+	sets []ResultSet
+	runs map[string]int
+	last time.Time
+}
+
+// NewReplayQuerier creates a querier answering with the result sets of the
+// recording.
+func NewReplayQuerier(r *Recording) *ReplayQuerier {
+	return &ReplayQuerier{sets: r.ResultSets, runs: map[string]int{}}
+}
+
+// Query implements Querier.
+func (q *ReplayQuerier) Query(ctx context.Context, query string, scan RowScanner, args ...interface{}) error {
+	set, err := q.Next(query, args...)
+	if err != nil {
+		return err
+	}
+	if err := set.err(); err != nil {
+		return err
+	}
+
+	for _, values := range set.Rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := scan(replayRow(values)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Connect implements Connector with the recorded logins. The connection
+// answers with the result sets of the recording, its logins and pings take
+// their recorded durations.
+func (q *ReplayQuerier) Connect(ctx context.Context, host mb.HostData) (Conn, error) {
+	set, err := q.Next(loginQuery)
+	if err != nil {
+		return nil, err
+	}
+	if err := set.err(); err != nil {
+		return nil, err
+	}
+	return &replayConn{ReplayQuerier: q, login: time.Duration(set.Duration) * time.Microsecond}, nil
+}
+
+// replayConn is a connection opened by a ReplayQuerier.
+type replayConn struct {
+	*ReplayQuerier
+	login time.Duration
+}
+
+func (c *replayConn) LoginDuration() time.Duration {
+	return c.login
+}
+
+func (c *replayConn) Ping(ctx context.Context) (time.Duration, error) {
+	set, err := c.Next(pingQuery)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(set.Duration) * time.Microsecond, set.err()
+}
+
+func (c *replayConn) Close() error {
+	return nil
+}
+
+// Next returns the result set answering the next run of the query with the
+// arguments, without scanning it.
+func (q *ReplayQuerier) Next(query string, args ...interface{}) (*ResultSet, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	normalized := normalizeQuery(query)
+	var matches []*ResultSet
+	withArgs := false
+	for i := range q.sets {
+		set := &q.sets[i]
+		if (set.Query != "" && normalizeQuery(set.Query) == normalized) ||
+			(set.Match != "" && strings.Contains(query, set.Match)) {
+			matches = append(matches, set)
+			withArgs = withArgs || set.Args != nil
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no recorded result for query: %s", normalized)
+	}
+
+	key := normalized
+	if withArgs {
+		replayed := argsKey(args)
+		var same []*ResultSet
+		for _, set := range matches {
+			if set.Args != nil && argsKey(set.Args) == replayed {
+				same = append(same, set)
+			}
+		}
+		if len(same) == 0 {
+			return nil, fmt.Errorf("no recorded result for arguments %s of query: %s", replayed, normalized)
+		}
+		matches = same
+		key += " " + replayed
+	}
+
+	run := q.runs[key]
+	q.runs[key]++
+	if run >= len(matches) {
+		run = len(matches) - 1
+	}
+	q.last = matches[run].Time
+	return matches[run], nil
+}
+
+// Time returns the time the last replayed result set was recorded.
+func (q *ReplayQuerier) Time() time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.last
+}
+
+// Runs returns the number of times the most recorded query was run, the
+// number of fetches in a recording of a metricset.
+func (q *ReplayQuerier) Runs() int {
+	counts := map[string]int{}
+	max := 0
+	for _, set := range q.sets {
+		key := normalizeQuery(set.Query)
+		if set.Query == "" {
+			key = "match " + set.Match
+		}
+		if set.Args != nil {
+			key += " " + argsKey(set.Args)
+		}
+		counts[key]++
+		if counts[key] > max {
+			max = counts[key]
+		}
+	}
+	return max
+}
+
+// argsKey returns the arguments of a query in their recorded form, to compare
+// the arguments of a run with the recorded ones.
+func argsKey(args []interface{}) string {
+	values := make([]string, len(args))
+	for i, arg := range args {
+		values[i] = fmt.Sprint(recordedValue(arg))
+	}
+	return fmt.Sprintf("%q", values)
+}
+
+// normalizeQuery collapses the whitespace of a query.
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// replayRow scans recorded values like database/sql scans the values of the
+// driver.
+type replayRow []interface{}
+
+func (r replayRow) Scan(dest ...interface{}) error {
+	if len(dest) != len(r) {
+		return fmt.Errorf("expected %d destination arguments in Scan, not %d", len(r), len(dest))
+	}
+	for i, d := range dest {
+		if err := assign(d, driverValue(r[i])); err != nil {
+			return fmt.Errorf("column %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// driverValue converts a recorded value to the type returned by a driver.
+func driverValue(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+func assign(dest, src interface{}) error {
+	// Times are recorded as strings, which sql.NullTime does not scan.
+	if t, ok := dest.(*sql.NullTime); ok {
+		*t = sql.NullTime{}
+		if src == nil {
+			return nil
+		}
+		t.Valid = true
+		return assign(&t.Time, src)
+	}
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(src)
+	}
+	if src == nil {
+		return fmt.Errorf("cannot scan NULL into %T", dest)
+	}
+
+	switch d := dest.(type) {
+	case *interface{}:
+		*d = src
+		return nil
+	case *string:
+		switch s := src.(type) {
+		case string:
+			*d = s
+		case []byte:
+			*d = string(s)
+		default:
+			*d = fmt.Sprint(s)
+		}
+		return nil
+	case *time.Time:
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("cannot scan %T into *time.Time", src)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		*d = t
+		return nil
+	}
+
+	// Numbers and booleans.
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("destination not a pointer: %T", dest)
+	}
+	dv = dv.Elem()
+	s := fmt.Sprint(src)
+	switch dv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot scan %v into %T: %v", src, dest, err)
+		}
+		dv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot scan %v into %T: %v", src, dest, err)
+		}
+		dv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot scan %v into %T: %v", src, dest, err)
+		}
+		dv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("cannot scan %v into %T: %v", src, dest, err)
+		}
+		dv.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported Scan destination %T", dest)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// +build !integration
+
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mssqldb "github.com/denisenkom/go-mssqldb"
+
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+func TestReplayQuerier(t *testing.T) {
+	q := NewReplayQuerier(&Recording{ResultSets: []ResultSet{
+		{Query: "SELECT  a,\n b FROM t", Rows: [][]interface{}{{json.Number("1"), "x"}}},
+		{Query: "SELECT a, b FROM t", Rows: [][]interface{}{{json.Number("2"), nil}}},
+		{Match: "FROM u", Rows: [][]interface{}{{json.Number("1.5"), "2019-06-01T10:00:00Z", true}}},
+		{Match: "FROM denied", Error: &RecordedError{Number: 229, Message: "denied"}},
+	}})
+	ctx := context.Background()
+
+	var values []int64
+	for i := 0; i < 3; i++ {
+		var a int64
+		var b sql.NullString
+		if err := QueryRow(ctx, q, "SELECT a, b\nFROM t", &a, &b); err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, a)
+	}
+	if values[0] != 1 || values[1] != 2 || values[2] != 2 {
+		t.Errorf("expected the result sets in turn then the last one repeated, got %v", values)
+	}
+
+	var f float64
+	var ts time.Time
+	var ok bool
+	if err := QueryRow(ctx, q, "SELECT f, ts, ok FROM u", &f, &ts, &ok); err != nil {
+		t.Fatal(err)
+	}
+	if f != 1.5 || !ts.Equal(time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)) || !ok {
+		t.Errorf("unexpected values %v %v %v", f, ts, ok)
+	}
+
+	var nt sql.NullTime
+	if err := QueryRow(ctx, q, "SELECT f, ts, ok FROM u", &f, &nt, &ok); err != nil {
+		t.Fatal(err)
+	}
+	if !nt.Valid || !nt.Time.Equal(ts) {
+		t.Errorf("expected the time %v, got %v", ts, nt)
+	}
+
+	var s string
+	if err := QueryRow(ctx, q, "SELECT a, b FROM t", &f, &s); err == nil {
+		t.Error("expected an error scanning NULL into a string")
+	}
+	if err := QueryRow(ctx, q, "SELECT 1 FROM denied", &f); !IsPermissionDenied(err) {
+		t.Errorf("expected the recorded error, got %v", err)
+	}
+	if _, ok := QueryRow(ctx, q, "SELECT 1 FROM missing", &f).(mssqldb.Error); ok {
+		t.Error("expected an error for a query not recorded")
+	}
+}
+
+func TestLoadRecordedResultSets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recording")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, recordingName("db1:1433/SQL2016.waits_2"))
+	lines := `{"time":"2019-06-01T03:00:00Z","query":"SELECT a FROM t","columns":["a"],"rows":[[1]]}
+{"time":"2019-06-01T03:00:00Z","query":"SELECT b FROM u","columns":["b"],"rows":[]}
+{"time":"2019-06-01T03:00:10Z","query":"SELECT a FROM t","columns":["a"],"rows":[[2]]}
+{"time":"2019-06-01T03:00:20Z","query":"SELECT a FROM t","error":{"number":0,"message":"i/o timeout"}}
+`
+	if err := ioutil.WriteFile(path, []byte(lines), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	metricset, name := RecordingMetricSet(path + ".3")
+	if metricset != "waits" || name != "db1_1433_SQL2016.waits_2.ndjson" {
+		t.Errorf("unexpected metricset %s and name %s of the recording", metricset, name)
+	}
+
+	r, err := LoadRecording(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewReplayQuerier(r)
+	if q.Runs() != 3 {
+		t.Errorf("expected 3 fetches, got %d", q.Runs())
+	}
+
+	var a int64
+	ctx := context.Background()
+	QueryRow(ctx, q, "SELECT a FROM t", &a)
+	if err := QueryRow(ctx, q, "SELECT a FROM t", &a); err != nil || a != 2 {
+		t.Errorf("expected the second result set, got %d, %v", a, err)
+	}
+	if !q.Time().Equal(time.Date(2019, 6, 1, 3, 0, 10, 0, time.UTC)) {
+		t.Errorf("unexpected time of the result set %v", q.Time())
+	}
+	if err := QueryRow(ctx, q, "SELECT a FROM t", &a); err == nil || err.Error() != "i/o timeout" {
+		t.Errorf("expected the recorded error, got %v", err)
+	}
+}
+
+func TestReplayQuerierArgs(t *testing.T) {
+	q := NewReplayQuerier(&Recording{ResultSets: []ResultSet{
+		{Match: "FROM t", Args: []interface{}{"inventory", json.Number("20")}, Rows: [][]interface{}{{json.Number("1")}}},
+		{Match: "FROM t", Args: []interface{}{"sales", json.Number("20")}, Rows: [][]interface{}{{json.Number("2")}}},
+		{Match: "FROM t", Args: []interface{}{"inventory", json.Number("20")}, Rows: [][]interface{}{{json.Number("3")}}},
+	}})
+
+	var values []interface{}
+	for _, database := range []string{"sales", "inventory", "inventory", "inventory"} {
+		set, err := q.Next("SELECT a FROM t", database, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, set.Rows[0][0])
+	}
+	expected := []interface{}{json.Number("2"), json.Number("1"), json.Number("3"), json.Number("3")}
+	for i := range expected {
+		if values[i] != expected[i] {
+			t.Errorf("expected the result sets of the arguments in turn %v, got %v", expected, values)
+			break
+		}
+	}
+
+	if _, err := q.Next("SELECT a FROM t", "sales", 50); err == nil {
+		t.Error("expected an error for arguments not recorded")
+	}
+	if q.Runs() != 2 {
+		t.Errorf("expected 2 fetches, got %d", q.Runs())
+	}
+}
+
+func TestReplayQuerierConnect(t *testing.T) {
+	q := NewReplayQuerier(&Recording{ResultSets: []ResultSet{
+		{Query: loginQuery, Rows: [][]interface{}{}, Duration: 4200},
+		{Query: loginQuery, Error: &RecordedError{Number: 18456, Message: "Login failed for user 'beat'."}},
+		{Query: pingQuery, Rows: [][]interface{}{{json.Number("1")}}, Duration: 350},
+	}})
+	ctx := context.Background()
+
+	conn, err := q.Connect(ctx, mb.HostData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.LoginDuration() != 4200*time.Microsecond {
+		t.Errorf("expected the recorded login duration, got %v", conn.LoginDuration())
+	}
+	if took, err := conn.Ping(ctx); err != nil || took != 350*time.Microsecond {
+		t.Errorf("expected the recorded ping duration, got %v, %v", took, err)
+	}
+	conn.Close()
+
+	if _, err := q.Connect(ctx, mb.HostData{}); ErrorClass(ctx, err) != ErrorClassLogin {
+		t.Errorf("expected the recorded login error, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package schedulers
+
+import (
+	"context"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "schedulers", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("schedulers", fields)
+	mssql.RequirePermissions("schedulers", mssql.ViewServerState)
+}
+
+// Schedulers running the user requests, without the hidden schedulers of the
+// system tasks and the scheduler of the dedicated admin connection.
+const schedulersQuery = `
+	SELECT
+		scheduler_id, cpu_id, parent_node_id, status, is_online,
+		current_tasks_count, runnable_tasks_count, current_workers_count, active_workers_count,
+		work_queue_count, pending_disk_io_count, load_factor
+	FROM sys.dm_os_schedulers
+	WHERE scheduler_id < 255
+`
+
+// Maximum and current number of workers of the server. When all the workers
+// are in use, new tasks wait on THREADPOOL.
+const workersQuery = `
+	SELECT si.max_workers_count, w.workers
+	FROM sys.dm_os_sys_info AS si
+	CROSS JOIN (SELECT COUNT(*) AS workers FROM sys.dm_os_workers) AS w
+`
+
+var fields = mssql.Field{
+	Name:        "schedulers",
+	Type:        "group",
+	Description: "`schedulers` contains the tasks and workers of a scheduler, or of the server.",
+	Fields: []mssql.Field{
+		{Name: "scheduler.id", Type: "integer", Description: "Id of the scheduler."},
+		{Name: "scheduler.cpu_id", Type: "integer", Description: "CPU the scheduler is assigned to."},
+		{Name: "scheduler.node_id", Type: "integer", Description: "NUMA node of the scheduler."},
+		{Name: "scheduler.status", Type: "keyword", Description: "Status of the scheduler, for example VISIBLE ONLINE."},
+		{Name: "scheduler.online", Type: "boolean", Description: "Whether the scheduler is online, it is offline when its CPU is excluded by the affinity mask."},
+		{Name: "scheduler.tasks.current", Type: "long", Description: "Number of tasks of the scheduler, running or waiting."},
+		{Name: "scheduler.tasks.runnable", Type: "long", Description: "Number of tasks waiting for the CPU of the scheduler."},
+		{Name: "scheduler.workers.current", Type: "long", Description: "Number of workers of the scheduler."},
+		{Name: "scheduler.workers.active", Type: "long", Description: "Number of workers of the scheduler running a task."},
+		{Name: "scheduler.work_queue.count", Type: "long", Description: "Number of tasks waiting for a worker of the scheduler."},
+		{Name: "scheduler.pending_disk_io.count", Type: "long", Description: "Number of disk IOs of the scheduler waiting to complete."},
+		{Name: "scheduler.load_factor", Type: "long", Description: "Load of the scheduler, used to assign the new tasks."},
+		{Name: "server.schedulers.count", Type: "integer", Description: "Number of online schedulers."},
+		{Name: "server.tasks.runnable", Type: "long", Description: "Number of tasks waiting for a CPU, on all the schedulers."},
+		{Name: "server.work_queue.count", Type: "long", Description: "Number of tasks waiting for a worker, on all the schedulers."},
+		{Name: "server.pending_disk_io.count", Type: "long", Description: "Number of disk IOs waiting to complete, on all the schedulers."},
+		{Name: "server.workers.max", Type: "long", Description: "Maximum number of workers of the server, the max worker threads setting or its default."},
+		{Name: "server.workers.current", Type: "long", Description: "Number of workers of the server."},
+		{Name: "server.workers.active", Type: "long", Description: "Number of workers running a task, on all the schedulers."},
+		{Name: "server.workers.exhaustion.pct", Type: "scaled_float", Format: "percent", Description: "Workers of the server as a fraction of the maximum, between 0 and 1. New tasks wait on THREADPOOL when it reaches 1."},
+	},
+}
+
+type scheduler struct {
+	id, cpuID, nodeID                    int64
+	status                               string
+	online                               bool
+	currentTasks, runnableTasks          int64
+	currentWorkers, activeWorkers        int64
+	workQueue, pendingDiskIO, loadFactor int64
+}
+
+// MetricSet reports the tasks and workers of the schedulers, and whether the
+// server runs out of workers.
+type MetricSet struct {
+	*mssql.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch reports one event per scheduler and an event with the summary of the
+// server.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	var schedulers []scheduler
+	err := m.Query(ctx, schedulersQuery, func(rows mssql.Rows) error {
+		var s scheduler
+		err := rows.Scan(&s.id, &s.cpuID, &s.nodeID, &s.status, &s.online,
+			&s.currentTasks, &s.runnableTasks, &s.currentWorkers, &s.activeWorkers,
+			&s.workQueue, &s.pendingDiskIO, &s.loadFactor)
+		if err != nil {
+			return err
+		}
+		schedulers = append(schedulers, s)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var maxWorkers, workers int64
+	err = m.Query(ctx, workersQuery, func(rows mssql.Rows) error {
+		return rows.Scan(&maxWorkers, &workers)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, s := range schedulers {
+		if !r.Event(mb.Event{MetricSetFields: s.fields()}) {
+			return nil
+		}
+	}
+	r.Event(mb.Event{MetricSetFields: serverFields(schedulers, maxWorkers, workers)})
+	return nil
+}
+
+func (s scheduler) fields() common.MapStr {
+	return common.MapStr{
+		"scheduler": common.MapStr{
+			"id":      s.id,
+			"cpu_id":  s.cpuID,
+			"node_id": s.nodeID,
+			"status":  s.status,
+			"online":  s.online,
+			"tasks": common.MapStr{
+				"current":  s.currentTasks,
+				"runnable": s.runnableTasks,
+			},
+			"workers": common.MapStr{
+				"current": s.currentWorkers,
+				"active":  s.activeWorkers,
+			},
+			"work_queue":      common.MapStr{"count": s.workQueue},
+			"pending_disk_io": common.MapStr{"count": s.pendingDiskIO},
+			"load_factor":     s.loadFactor,
+		},
+	}
+}
+
+// serverFields sums up the schedulers that are online, and compares the
+// workers of the server to their maximum.
+func serverFields(schedulers []scheduler, maxWorkers, workers int64) common.MapStr {
+	var online, runnable, workQueue, pendingDiskIO, active int64
+	for _, s := range schedulers {
+		if !s.online {
+			continue
+		}
+		online++
+		runnable += s.runnableTasks
+		workQueue += s.workQueue
+		pendingDiskIO += s.pendingDiskIO
+		active += s.activeWorkers
+	}
+
+	workerFields := common.MapStr{
+		"max":     maxWorkers,
+		"current": workers,
+		"active":  active,
+	}
+	if maxWorkers > 0 {
+		workerFields.Put("exhaustion.pct", float64(workers)/float64(maxWorkers))
+	}
+	return common.MapStr{
+		"server": common.MapStr{
+			"schedulers":      common.MapStr{"count": online},
+			"tasks":           common.MapStr{"runnable": runnable},
+			"work_queue":      common.MapStr{"count": workQueue},
+			"pending_disk_io": common.MapStr{"count": pendingDiskIO},
+			"workers":         workerFields,
+		},
+	}
+}
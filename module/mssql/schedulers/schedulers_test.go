@@ -0,0 +1,33 @@
+// +build !integration
+
+package schedulers
+
+import (
+	"testing"
+)
+
+func TestServerFields(t *testing.T) {
+	schedulers := []scheduler{
+		{online: true, runnableTasks: 3, workQueue: 2, activeWorkers: 10},
+		{online: true, runnableTasks: 1, workQueue: 0, activeWorkers: 4},
+		{online: false, runnableTasks: 5, workQueue: 5, activeWorkers: 5},
+	}
+	fields := serverFields(schedulers, 512, 384)
+
+	expected := map[string]interface{}{
+		"server.schedulers.count":       int64(2),
+		"server.tasks.runnable":         int64(4),
+		"server.work_queue.count":       int64(2),
+		"server.workers.active":         int64(14),
+		"server.workers.exhaustion.pct": 0.75,
+	}
+	for key, value := range expected {
+		if v, _ := fields.GetValue(key); v != value {
+			t.Errorf("expected %s %v, got %v", key, value, v)
+		}
+	}
+
+	if _, err := serverFields(nil, 0, 10).GetValue("server.workers.exhaustion.pct"); err == nil {
+		t.Error("expected no exhaustion without a maximum")
+	}
+}
@@ -0,0 +1,128 @@
+package spinlocks
+
+import (
+	"context"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "spinlocks", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("spinlocks", fields)
+	mssql.RequirePermissions("spinlocks", mssql.ViewServerState)
+}
+
+// Spinlock statistics, without the spinlocks that never collided.
+const query = `
+	SELECT name, collisions, spins, backoffs
+	FROM sys.dm_os_spinlock_stats
+	WHERE collisions > 0
+`
+
+var fields = mssql.Field{
+	Name:        "spinlocks",
+	Type:        "group",
+	Description: "`spinlocks` contains the statistics of a spinlock type.",
+	Fields: []mssql.Field{
+		{Name: "name", Type: "keyword", Description: "Name of the spinlock type."},
+		{Name: "collisions", Type: "long", Description: "Number of times a thread tried to acquire the spinlock while another thread held it."},
+		{Name: "spins", Type: "long", Description: "Number of loops of the threads spinning to acquire the spinlock."},
+		{Name: "backoffs", Type: "long", Description: "Number of times a spinning thread yielded the CPU before trying again."},
+		{Name: "interval.collisions", Type: "long", Description: "Number of collisions since the previous fetch."},
+		{Name: "interval.spins", Type: "long", Description: "Number of spins since the previous fetch."},
+		{Name: "interval.backoffs", Type: "long", Description: "Number of backoffs since the previous fetch."},
+		{Name: "interval.spins_per_collision", Type: "scaled_float", Description: "Average number of spins per collision since the previous fetch."},
+	},
+}
+
+type spinlockStats struct {
+	name                        string
+	collisions, spins, backoffs int64
+
+	// Increase since the previous fetch.
+	interval []int64
+}
+
+// MetricSet reports the spinlock types that spun the most since the previous
+// fetch.
+type MetricSet struct {
+	*mssql.MetricSet
+	top *mssql.Top
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Top int `config:"spinlocks.top" validate:"min=1"`
+	}{20}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	// The spinlock types that collided, by number of spins.
+	return &MetricSet{MetricSet: ms, top: mssql.NewTop(config.Top, 0, 1)}, nil
+}
+
+// Fetch reports one event per spinlock type among the spinlocks.top that spun
+// the most since the previous fetch. Nothing is reported on the first fetch.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+// NeedsBaseline is true until the statistics needed to compute the interval
+// values were fetched once.
+func (m *MetricSet) NeedsBaseline() bool {
+	return !m.top.Baseline()
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	stats := map[string]spinlockStats{}
+	err := m.Query(ctx, query, func(rows mssql.Rows) error {
+		var s spinlockStats
+		if err := rows.Scan(&s.name, &s.collisions, &s.spins, &s.backoffs); err != nil {
+			return err
+		}
+		stats[s.name] = s
+		m.top.Add(s.name, s.collisions, s.spins, s.backoffs)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, increase := range m.top.Next() {
+		s := stats[increase.Key]
+		s.interval = increase.Deltas
+		if !r.Event(mb.Event{MetricSetFields: s.fields()}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s spinlockStats) fields() common.MapStr {
+	collisions, spins, backoffs := s.interval[0], s.interval[1], s.interval[2]
+	return common.MapStr{
+		"name":       s.name,
+		"collisions": s.collisions,
+		"spins":      s.spins,
+		"backoffs":   s.backoffs,
+		"interval": common.MapStr{
+			"collisions":          collisions,
+			"spins":               spins,
+			"backoffs":            backoffs,
+			"spins_per_collision": float64(spins) / float64(collisions),
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package statistics
+
+import (
+	"context"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "statistics", New,
+		mb.WithHostParser(mssql.ParseURL),
+	)
+	mssql.RegisterFields("statistics", fields)
+	mssql.RequirePermissions("statistics", mssql.ViewAnyDefinition, mssql.DatabaseAccess, mssql.DatabaseReader)
+}
+
+// Statistics of the user tables of at least @p2 rows, with at least @p3
+// modifications per row since they were updated. The update time is
+// converted from the server local time to UTC.
+var statisticsQuery = mssql.DatabaseQuery(`
+	SELECT
+		SCHEMA_NAME(o.schema_id), o.name, s.name, s.auto_created,
+		DATEADD(MINUTE, DATEDIFF(MINUTE, GETDATE(), GETUTCDATE()), sp.last_updated),
+		sp.rows, sp.rows_sampled, sp.modification_counter
+	FROM sys.stats AS s
+	JOIN sys.objects AS o ON o.object_id = s.object_id
+	CROSS APPLY sys.dm_db_stats_properties(s.object_id, s.stats_id) AS sp
+	WHERE o.type = 'U' AND o.is_ms_shipped = 0
+		AND sp.rows >= @p2 AND sp.modification_counter >= @p3 * sp.rows
+	ORDER BY sp.modification_counter DESC
+`, "@p2 bigint, @p3 float")
+
+var fields = mssql.Field{
+	Name:        "statistics",
+	Type:        "group",
+	Description: "`statistics` contains the staleness of a statistics object of a table.",
+	Fields: []mssql.Field{
+		{Name: "schema", Type: "keyword", Description: "Schema of the table."},
+		{Name: "table", Type: "keyword", Description: "Name of the table."},
+		{Name: "name", Type: "keyword", Description: "Name of the statistics."},
+		{Name: "auto_created", Type: "boolean", Description: "Whether the statistics were created by the optimizer."},
+		{Name: "last_updated", Type: "date", Description: "Time the statistics were last updated."},
+		{Name: "rows.count", Type: "long", Description: "Number of rows of the table when the statistics were last updated."},
+		{Name: "rows_sampled.count", Type: "long", Description: "Number of rows sampled to update the statistics."},
+		{Name: "sampled.pct", Type: "scaled_float", Format: "percent", Description: "Rows sampled as a percentage of the rows of the table."},
+		{Name: "modifications.count", Type: "long", Description: "Number of modifications of the leading column of the statistics since they were last updated."},
+		{Name: "staleness", Type: "scaled_float", Description: "Modifications per row of the table since the statistics were last updated, above 1 when the table changed more than once over."},
+	},
+}
+
+type statistics struct {
+	schema, table, name string
+	autoCreated         bool
+	lastUpdated         time.Time
+	rows, sampled       int64
+	modifications       int64
+}
+
+// MetricSet reports the statistics of the large tables of every database
+// with their staleness. It reads every statistics object and is meant to run
+// with a long period.
+type MetricSet struct {
+	*mssql.MetricSet
+	budget       time.Duration
+	minRows      int64
+	minStaleness float64
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Budget       time.Duration `config:"statistics.database_timeout" validate:"positive"`
+		MinRows      int64         `config:"statistics.min_rows" validate:"min=1"`
+		MinStaleness float64       `config:"statistics.min_staleness" validate:"min=0"`
+	}{time.Minute, 100000, 0}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, budget: config.Budget, minRows: config.MinRows, minStaleness: config.MinStaleness}, nil
+}
+
+// Fetch reports, for every user database, one event per statistics object of
+// the tables of at least statistics.min_rows rows, with a staleness of at
+// least statistics.min_staleness. A database taking longer than
+// statistics.database_timeout is skipped.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	return m.EachDatabase(ctx, m.budget, func(ctx context.Context, database string) error {
+		// Events are only sent once the database was collected, a database
+		// skipped after its budget is not partially reported.
+		var stats []statistics
+		err := m.Query(ctx, statisticsQuery, func(rows mssql.Rows) error {
+			var s statistics
+			if err := rows.Scan(&s.schema, &s.table, &s.name, &s.autoCreated, &s.lastUpdated, &s.rows, &s.sampled, &s.modifications); err != nil {
+				return err
+			}
+			stats = append(stats, s)
+			return nil
+		}, database, m.minRows, m.minStaleness)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range stats {
+			event := mb.Event{
+				ModuleFields: common.MapStr{
+					"database": common.MapStr{
+						"name": database,
+					},
+				},
+				MetricSetFields: s.fields(),
+			}
+			if !r.Event(event) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (s statistics) fields() common.MapStr {
+	fields := common.MapStr{
+		"schema":        s.schema,
+		"table":         s.table,
+		"name":          s.name,
+		"auto_created":  s.autoCreated,
+		"last_updated":  common.Time(s.lastUpdated),
+		"rows":          common.MapStr{"count": s.rows},
+		"rows_sampled":  common.MapStr{"count": s.sampled},
+		"modifications": common.MapStr{"count": s.modifications},
+	}
+	if s.rows > 0 {
+		fields.Put("sampled.pct", float64(s.sampled)/float64(s.rows))
+		fields.Put("staleness", s.staleness())
+	}
+	return fields
+}
+
+// staleness returns the modifications per row since the statistics were last
+// updated.
+func (s statistics) staleness() float64 {
+	return float64(s.modifications) / float64(s.rows)
+}
@@ -0,0 +1,22 @@
+// +build !integration
+
+package statistics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStaleness(t *testing.T) {
+	s := statistics{
+		schema: "dbo", table: "Orders", name: "IX_Orders_CustomerId",
+		lastUpdated: time.Date(2023, 11, 20, 2, 0, 41, 0, time.UTC),
+		rows:        15000000, sampled: 1500000, modifications: 9000000,
+	}
+	if staleness := s.staleness(); staleness != 0.6 {
+		t.Errorf("expected a staleness of 0.6, got %v", staleness)
+	}
+	if sampled, _ := s.fields().GetValue("sampled.pct"); sampled != 0.1 {
+		t.Errorf("expected 10%% of the rows sampled, got %v", sampled)
+	}
+}
@@ -0,0 +1,199 @@
+package mssql
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/metricbeat/mb"
+)
+
+// Upper bounds of the query duration histogram buckets.
+var queryDurationBuckets = []time.Duration{
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Stats tracks the collection health of a metricset for one host. The
+// metrics are registered under mssql.<host>.<metricset> in the stats
+// registry, so they are exposed by the HTTP stats endpoint and reported with
+// the monitoring data.
+type Stats struct {
+	key string
+
+	success   *monitoring.Int // Fetches that completed.
+	failures  *monitoring.Int // Fetches that reported an error.
+	skipped   *monitoring.Int // Fetches that did not run because the previous one overran the period.
+	rows      *monitoring.Int // Rows read from SQL Server.
+	events    *monitoring.Int // Events published.
+	lastError *monitoring.String
+	missing   *monitoring.String // Permissions the login lacks, the metricset does not fetch without them.
+
+	queryDuration *durationHistogram
+
+	period    time.Duration
+	lastFetch time.Time
+}
+
+var (
+	metricsLock sync.Mutex
+	mssqlStats  = monitoring.Default.NewRegistry("mssql", monitoring.Report)
+)
+
+// NewStats registers the metrics of the metricset. The connection pool
+// statistics of db are reported along with them.
+func NewStats(ms mb.MetricSet, db *sql.DB) *Stats {
+	metricsLock.Lock()
+	defer metricsLock.Unlock()
+
+	// The same metricset can be configured more than once for a host, each
+	// of them gets its own metrics.
+	base := metricsHostKey(ms.HostData()) + "." + ms.Name()
+	key := base
+	for i := 2; mssqlStats.GetRegistry(key) != nil; i++ {
+		key = fmt.Sprintf("%s_%d", base, i)
+	}
+	reg := mssqlStats.NewRegistry(key, monitoring.Report)
+
+	m := &Stats{
+		key:           key,
+		success:       monitoring.NewInt(reg, "success"),
+		failures:      monitoring.NewInt(reg, "failures"),
+		skipped:       monitoring.NewInt(reg, "skipped"),
+		rows:          monitoring.NewInt(reg, "rows"),
+		events:        monitoring.NewInt(reg, "events"),
+		lastError:     monitoring.NewString(reg, "last_error"),
+		missing:       monitoring.NewString(reg, "missing_permissions"),
+		queryDuration: &durationHistogram{buckets: make([]int64, len(queryDurationBuckets)+1)},
+		period:        ms.Module().Config().Period,
+	}
+	monitoring.NewFunc(reg, "query_duration", m.queryDuration.report, monitoring.Report)
+	monitoring.NewFunc(reg, "pool", func(_ monitoring.Mode, V monitoring.Visitor) {
+		reportPoolStats(V, db.Stats())
+	}, monitoring.Report)
+
+	return m
+}
+
+// metricsHostKey returns the key of a host in the registry. Dots would create
+// nested registries, so they are replaced.
+func metricsHostKey(host mb.HostData) string {
+	key := host.Host
+	if u, err := url.Parse(host.SanitizedURI); err == nil && len(u.Path) > 1 {
+		key += "/" + u.Path[1:]
+	}
+	return strings.Replace(key, ".", "_", -1)
+}
+
+// FetchStarted records the start of a fetch, counting the fetches that were
+// skipped since the previous one.
+func (m *Stats) FetchStarted(start time.Time) {
+	if !m.lastFetch.IsZero() && m.period > 0 {
+		// Tickers drop the ticks that happen while a fetch is still running.
+		if missed := int64(start.Sub(m.lastFetch)/m.period) - 1; missed > 0 {
+			m.skipped.Add(missed)
+		}
+	}
+	m.lastFetch = start
+}
+
+// FetchDone records the outcome of a fetch.
+func (m *Stats) FetchDone(err error) {
+	if err != nil {
+		m.failures.Inc()
+		m.lastError.Fail(err)
+		return
+	}
+	m.success.Inc()
+}
+
+// PermissionsChecked records the permissions the login lacks.
+func (m *Stats) PermissionsChecked(missing []string) {
+	m.missing.Set(strings.Join(missing, ", "))
+}
+
+// QueryDone records the duration of a query and the number of rows it read.
+func (m *Stats) QueryDone(took time.Duration, rows int) {
+	m.queryDuration.record(took)
+	m.rows.Add(int64(rows))
+}
+
+// EventPublished counts a published event.
+func (m *Stats) EventPublished() {
+	m.events.Inc()
+}
+
+// Close removes the metrics from the registry.
+func (m *Stats) Close() {
+	metricsLock.Lock()
+	defer metricsLock.Unlock()
+
+	mssqlStats.Remove(m.key)
+}
+
+func reportPoolStats(V monitoring.Visitor, stats sql.DBStats) {
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	monitoring.ReportInt(V, "max_open", int64(stats.MaxOpenConnections))
+	monitoring.ReportInt(V, "open", int64(stats.OpenConnections))
+	monitoring.ReportInt(V, "in_use", int64(stats.InUse))
+	monitoring.ReportInt(V, "idle", int64(stats.Idle))
+	monitoring.ReportInt(V, "wait_count", stats.WaitCount)
+	monitoring.ReportInt(V, "wait_duration_ms", int64(stats.WaitDuration/time.Millisecond))
+	monitoring.ReportInt(V, "max_idle_closed", stats.MaxIdleClosed)
+	monitoring.ReportInt(V, "max_lifetime_closed", stats.MaxLifetimeClosed)
+}
+
+// durationHistogram counts durations in fixed buckets.
+type durationHistogram struct {
+	mu      sync.Mutex
+	count   int64
+	total   time.Duration
+	max     time.Duration
+	buckets []int64 // Counts per bucket, the last one is unbounded.
+}
+
+func (h *durationHistogram) record(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.count++
+	h.total += d
+	if d > h.max {
+		h.max = d
+	}
+
+	i := 0
+	for i < len(queryDurationBuckets) && d > queryDurationBuckets[i] {
+		i++
+	}
+	h.buckets[i]++
+}
+
+func (h *durationHistogram) report(_ monitoring.Mode, V monitoring.Visitor) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	V.OnRegistryStart()
+	defer V.OnRegistryFinished()
+
+	monitoring.ReportInt(V, "count", h.count)
+	monitoring.ReportInt(V, "total_ms", int64(h.total/time.Millisecond))
+	monitoring.ReportInt(V, "max_ms", int64(h.max/time.Millisecond))
+	monitoring.ReportNamespace(V, "buckets", func() {
+		for i, bound := range queryDurationBuckets {
+			monitoring.ReportInt(V, fmt.Sprintf("le_%dms", bound/time.Millisecond), h.buckets[i])
+		}
+		monitoring.ReportInt(V, "inf", h.buckets[len(queryDurationBuckets)])
+	})
+}
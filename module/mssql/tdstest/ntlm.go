@@ -0,0 +1,108 @@
+package tdstest
+
+import (
+	"bytes"
+	"crypto/des"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/md4"
+)
+
+// NTLM flags sent in the challenge, the server only supports the NTLM session
+// response of the extended session security.
+const (
+	ntlmNegotiateUnicode         = 0x00000001
+	ntlmNegotiateNTLM            = 0x00000200
+	ntlmNegotiateAlwaysSign      = 0x00008000
+	ntlmNegotiateExtendedSession = 0x00080000
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// ntlmChallenge returns a CHALLENGE message with a random server challenge.
+func ntlmChallenge() (msg []byte, challenge [8]byte) {
+	rand.Read(challenge[:])
+	msg = make([]byte, 48)
+	copy(msg, ntlmSignature)
+	binary.LittleEndian.PutUint32(msg[8:], 2)
+	binary.LittleEndian.PutUint32(msg[16:], 48) // empty target name
+	binary.LittleEndian.PutUint32(msg[20:], ntlmNegotiateUnicode|ntlmNegotiateNTLM|ntlmNegotiateAlwaysSign|ntlmNegotiateExtendedSession)
+	copy(msg[24:], challenge[:])
+	binary.LittleEndian.PutUint32(msg[44:], 48) // empty target info
+	return msg, challenge
+}
+
+// ntlmAuthenticate checks an AUTHENTICATE message against the passwords of
+// the DOMAIN\user logins. It returns the login.
+func ntlmAuthenticate(msg []byte, challenge [8]byte, logins map[string]string) (string, error) {
+	if len(msg) < 52 || !bytes.HasPrefix(msg, ntlmSignature) || binary.LittleEndian.Uint32(msg[8:]) != 3 {
+		return "", errors.New("invalid NTLM authenticate message")
+	}
+	field := func(pos int) []byte {
+		length := int(binary.LittleEndian.Uint16(msg[pos:]))
+		offset := int(binary.LittleEndian.Uint32(msg[pos+4:]))
+		if offset+length > len(msg) {
+			return nil
+		}
+		return msg[offset : offset+length]
+	}
+	lm, nt := field(12), field(20)
+	user := ucs2String(field(28)) + `\` + ucs2String(field(36))
+	if len(lm) < 8 || len(nt) != 24 {
+		return user, errors.New("unsupported NTLM response")
+	}
+
+	for login, password := range logins {
+		if !strings.EqualFold(login, user) {
+			continue
+		}
+		var clientNonce [8]byte
+		copy(clientNonce[:], lm)
+		expected := ntlmSessionResponse(clientNonce, challenge, password)
+		if bytes.Equal(nt, expected[:]) {
+			return login, nil
+		}
+	}
+	return user, errors.New("wrong NTLM response")
+}
+
+// ntlmSessionResponse computes the NTLM response with extended session
+// security: the NT hash of the password encrypts the first 8 bytes of the MD5
+// of the challenges.
+func ntlmSessionResponse(clientNonce, serverChallenge [8]byte, password string) [24]byte {
+	session := md5.Sum(append(serverChallenge[:], clientNonce[:]...))
+
+	var hash [21]byte
+	h := md4.New()
+	h.Write(ucs2(password))
+	h.Sum(hash[:0])
+
+	var res [24]byte
+	for i := 0; i < 3; i++ {
+		desEncrypt(hash[7*i:7*i+7], session[:8], res[8*i:8*i+8])
+	}
+	return res
+}
+
+// desEncrypt encrypts a block with a DES key made from 7 bytes.
+func desEncrypt(key7, src, dst []byte) {
+	key := []byte{
+		key7[0],
+		key7[0]<<7 | key7[1]>>1,
+		key7[1]<<6 | key7[2]>>2,
+		key7[2]<<5 | key7[3]>>3,
+		key7[3]<<4 | key7[4]>>4,
+		key7[4]<<3 | key7[5]>>5,
+		key7[5]<<2 | key7[6]>>6,
+		key7[6] << 1,
+	}
+	block, err := des.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	block.Encrypt(dst, src)
+}
@@ -0,0 +1,520 @@
+package tdstest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Packet types of the TDS protocol.
+const (
+	packetSQLBatch    = 1
+	packetRPC         = 3
+	packetReply       = 4
+	packetAttention   = 6
+	packetTransaction = 14
+	packetLogin7      = 16
+	packetSSPI        = 17
+	packetPrelogin    = 18
+)
+
+// packetSize is the size of the packets sent by the server, the default
+// packet size of the clients.
+const packetSize = 4096
+
+const headerSize = 8
+
+// statusEOM marks the last packet of a message.
+const statusEOM = 1
+
+// readMessage reads the packets of a message and returns its type and
+// payload.
+func readMessage(r io.Reader) (byte, []byte, error) {
+	var payload []byte
+	for {
+		var header [headerSize]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return 0, nil, err
+		}
+		size := int(binary.BigEndian.Uint16(header[2:]))
+		if size < headerSize {
+			return 0, nil, fmt.Errorf("invalid packet size %d", size)
+		}
+		data := make([]byte, size-headerSize)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return 0, nil, err
+		}
+		payload = append(payload, data...)
+		if header[1]&statusEOM != 0 {
+			return header[0], payload, nil
+		}
+	}
+}
+
+// writeMessage splits the payload of a message in packets.
+func writeMessage(w io.Writer, packetType byte, payload []byte) error {
+	var buf bytes.Buffer
+	for seq := 1; ; seq++ {
+		n := len(payload)
+		if n > packetSize-headerSize {
+			n = packetSize - headerSize
+		}
+		status := byte(0)
+		if n == len(payload) {
+			status = statusEOM
+		}
+
+		buf.Reset()
+		buf.Write([]byte{packetType, status})
+		binary.Write(&buf, binary.BigEndian, uint16(n+headerSize))
+		buf.Write([]byte{0, 0, byte(seq), 0})
+		buf.Write(payload[:n])
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+
+		payload = payload[n:]
+		if status == statusEOM {
+			return nil
+		}
+	}
+}
+
+// Prelogin options.
+const (
+	preloginVersion    = 0
+	preloginEncryption = 1
+	preloginInstance   = 2
+	preloginThreadID   = 3
+	preloginMARS       = 4
+	preloginTerminator = 0xff
+)
+
+// Encryption options of the prelogin.
+const (
+	encryptOff    = 0
+	encryptOn     = 1
+	encryptNotSup = 2
+	encryptReq    = 3
+)
+
+func parsePrelogin(payload []byte) (map[byte][]byte, error) {
+	options := map[byte][]byte{}
+	for i := 0; i < len(payload); i += 5 {
+		if payload[i] == preloginTerminator {
+			return options, nil
+		}
+		if i+5 > len(payload) {
+			break
+		}
+		offset := int(binary.BigEndian.Uint16(payload[i+1:]))
+		length := int(binary.BigEndian.Uint16(payload[i+3:]))
+		if offset+length > len(payload) {
+			break
+		}
+		options[payload[i]] = payload[offset : offset+length]
+	}
+	return nil, errors.New("invalid prelogin message")
+}
+
+func buildPrelogin(options map[byte][]byte) []byte {
+	keys := []byte{preloginVersion, preloginEncryption, preloginInstance, preloginThreadID, preloginMARS}
+	var header, data bytes.Buffer
+	offset := len(keys)*5 + 1
+	for _, k := range keys {
+		v := options[k]
+		header.WriteByte(k)
+		binary.Write(&header, binary.BigEndian, uint16(offset+data.Len()))
+		binary.Write(&header, binary.BigEndian, uint16(len(v)))
+		data.Write(v)
+	}
+	header.WriteByte(preloginTerminator)
+	return append(header.Bytes(), data.Bytes()...)
+}
+
+// login is the part of a LOGIN7 message the server uses.
+type login struct {
+	hostName, userName, password, appName, database string
+	integratedSecurity                              bool
+	sspi                                            []byte
+}
+
+func parseLogin7(payload []byte) (*login, error) {
+	if len(payload) < 94 {
+		return nil, errors.New("login message too short")
+	}
+	field := func(pos int, chars bool) ([]byte, error) {
+		offset := int(binary.LittleEndian.Uint16(payload[pos:]))
+		length := int(binary.LittleEndian.Uint16(payload[pos+2:]))
+		if chars {
+			length *= 2
+		}
+		if offset+length > len(payload) {
+			return nil, errors.New("invalid login message")
+		}
+		return payload[offset : offset+length], nil
+	}
+	str := func(pos int) (string, error) {
+		b, err := field(pos, true)
+		return ucs2String(b), err
+	}
+
+	var l login
+	var err error
+	if l.hostName, err = str(36); err != nil {
+		return nil, err
+	}
+	if l.userName, err = str(40); err != nil {
+		return nil, err
+	}
+	password, err := field(44, true)
+	if err != nil {
+		return nil, err
+	}
+	l.password = ucs2String(demanglePassword(password))
+	if l.appName, err = str(48); err != nil {
+		return nil, err
+	}
+	if l.database, err = str(68); err != nil {
+		return nil, err
+	}
+	l.integratedSecurity = payload[25]&0x80 != 0
+	if l.sspi, err = field(78, false); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// demanglePassword reverses the nibble swap and XOR of the LOGIN7 passwords.
+func demanglePassword(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i, c := range b {
+		c ^= 0xa5
+		res[i] = c<<4 | c>>4
+	}
+	return res
+}
+
+// parseSQLBatch returns the text of a SQL batch, after its headers.
+func parseSQLBatch(payload []byte) (string, error) {
+	if len(payload) < 4 {
+		return "", errors.New("batch message too short")
+	}
+	headers := int(binary.LittleEndian.Uint32(payload))
+	if headers > len(payload) {
+		return "", errors.New("invalid batch headers")
+	}
+	return ucs2String(payload[headers:]), nil
+}
+
+func ucs2(s string) []byte {
+	chars := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(chars))
+	for i, c := range chars {
+		binary.LittleEndian.PutUint16(b[2*i:], c)
+	}
+	return b
+}
+
+func ucs2String(b []byte) string {
+	chars := make([]uint16, len(b)/2)
+	for i := range chars {
+		chars[i] = binary.LittleEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(chars))
+}
+
+// Tokens of the replies.
+const (
+	tokenColMetadata = 0x81
+	tokenError       = 0xaa
+	tokenLoginAck    = 0xad
+	tokenRow         = 0xd1
+	tokenEnvChange   = 0xe3
+	tokenSSPI        = 0xed
+	tokenDone        = 0xfd
+)
+
+// Status flags of the DONE token.
+const (
+	doneFinal = 0
+	doneError = 0x2
+	doneCount = 0x10
+	doneAttn  = 0x20
+)
+
+const curCmdSelect = 0xc1
+
+// reply builds the tokens of a reply message.
+type reply struct {
+	bytes.Buffer
+}
+
+func (r *reply) uint16(v uint16) { binary.Write(r, binary.LittleEndian, v) }
+func (r *reply) uint32(v uint32) { binary.Write(r, binary.LittleEndian, v) }
+
+// bVarChar writes a string prefixed by its length in characters on a byte.
+func (r *reply) bVarChar(s string) {
+	b := ucs2(s)
+	r.WriteByte(byte(len(b) / 2))
+	r.Write(b)
+}
+
+// usVarChar writes a string prefixed by its length in characters on two
+// bytes.
+func (r *reply) usVarChar(s string) {
+	b := ucs2(s)
+	r.uint16(uint16(len(b) / 2))
+	r.Write(b)
+}
+
+// token writes a token with a length prefix.
+func (r *reply) token(token byte, body func(t *reply)) {
+	var t reply
+	body(&t)
+	r.WriteByte(token)
+	r.uint16(uint16(t.Len()))
+	r.Write(t.Bytes())
+}
+
+func (r *reply) envChange(envType byte, newValue, oldValue string) {
+	r.token(tokenEnvChange, func(t *reply) {
+		t.WriteByte(envType)
+		t.bVarChar(newValue)
+		t.bVarChar(oldValue)
+	})
+}
+
+func (r *reply) loginAck(version [4]byte) {
+	r.token(tokenLoginAck, func(t *reply) {
+		t.WriteByte(1) // SQL interface
+		t.Write([]byte{0x74, 0, 0, 4})
+		t.bVarChar("Microsoft SQL Server")
+		t.Write(version[:])
+	})
+}
+
+func (r *reply) sspi(data []byte) {
+	r.WriteByte(tokenSSPI)
+	r.uint16(uint16(len(data)))
+	r.Write(data)
+}
+
+// Error is a server error returned in an ERROR token.
+type Error struct {
+	Number  int32
+	State   byte
+	Class   byte
+	Message string
+}
+
+func (r *reply) error(server string, e Error) {
+	r.token(tokenError, func(t *reply) {
+		t.uint32(uint32(e.Number))
+		t.WriteByte(e.State)
+		t.WriteByte(e.Class)
+		t.usVarChar(e.Message)
+		t.bVarChar(server)
+		t.bVarChar("")
+		t.uint32(1)
+	})
+}
+
+func (r *reply) done(status, curCmd uint16, rows uint64) {
+	r.WriteByte(tokenDone)
+	r.uint16(status)
+	r.uint16(curCmd)
+	binary.Write(r, binary.LittleEndian, rows)
+}
+
+// Data types of the result set columns.
+const (
+	typeIntN      = 0x26
+	typeBitN      = 0x68
+	typeFltN      = 0x6d
+	typeDateTime2 = 0x2a
+	typeNVarChar  = 0xe7
+)
+
+// collation is Latin1_General_CI_AS.
+var collation = []byte{0x09, 0x04, 0xd0, 0x00, 0x34}
+
+// columnType infers the type of a column from its values: bigint, float, bit,
+// datetime2 for the strings in RFC 3339 format, nvarchar otherwise.
+func columnType(rows [][]interface{}, col int) byte {
+	t := byte(0)
+	for _, row := range rows {
+		if col >= len(row) || row[col] == nil {
+			continue
+		}
+		var vt byte
+		switch v := row[col].(type) {
+		case json.Number:
+			vt = typeIntN
+			if _, err := v.Int64(); err != nil {
+				vt = typeFltN
+			}
+		case int, int32, int64:
+			vt = typeIntN
+		case float32, float64:
+			vt = typeFltN
+		case bool:
+			vt = typeBitN
+		case time.Time:
+			vt = typeDateTime2
+		case string:
+			vt = typeNVarChar
+			if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				vt = typeDateTime2
+			}
+		default:
+			vt = typeNVarChar
+		}
+		switch {
+		case t == 0, t == typeIntN && vt == typeFltN:
+			t = vt
+		case t == typeFltN && vt == typeIntN:
+		case t != vt:
+			t = typeNVarChar
+		}
+	}
+	if t == 0 {
+		t = typeNVarChar
+	}
+	return t
+}
+
+func (r *reply) colMetadata(names []string, types []byte) {
+	r.WriteByte(tokenColMetadata)
+	r.uint16(uint16(len(types)))
+	for i, t := range types {
+		r.uint32(0)   // user type
+		r.uint16(0x1) // nullable
+		r.WriteByte(t)
+		switch t {
+		case typeIntN, typeFltN:
+			r.WriteByte(8)
+		case typeBitN:
+			r.WriteByte(1)
+		case typeDateTime2:
+			r.WriteByte(7) // scale
+		case typeNVarChar:
+			r.uint16(8000)
+			r.Write(collation)
+		}
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+		r.bVarChar(name)
+	}
+}
+
+func (r *reply) row(types []byte, values []interface{}) error {
+	r.WriteByte(tokenRow)
+	for i, t := range types {
+		var v interface{}
+		if i < len(values) {
+			v = values[i]
+		}
+		if err := r.value(t, v); err != nil {
+			return fmt.Errorf("column %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *reply) value(t byte, v interface{}) error {
+	if v == nil {
+		if t == typeNVarChar {
+			r.uint16(0xffff)
+		} else {
+			r.WriteByte(0)
+		}
+		return nil
+	}
+
+	s := fmt.Sprint(v)
+	switch t {
+	case typeIntN:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		r.WriteByte(8)
+		binary.Write(r, binary.LittleEndian, i)
+	case typeFltN:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		r.WriteByte(8)
+		binary.Write(r, binary.LittleEndian, math.Float64bits(f))
+	case typeBitN:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		r.WriteByte(1)
+		if b {
+			r.WriteByte(1)
+		} else {
+			r.WriteByte(0)
+		}
+	case typeDateTime2:
+		ts, ok := v.(time.Time)
+		if !ok {
+			var err error
+			if ts, err = time.Parse(time.RFC3339Nano, s); err != nil {
+				return err
+			}
+		}
+		r.WriteByte(8)
+		r.Write(dateTime2(ts.UTC()))
+	case typeNVarChar:
+		b := ucs2(s)
+		if len(b) > 8000 {
+			return errors.New("string longer than 4000 characters")
+		}
+		r.uint16(uint16(len(b)))
+		r.Write(b)
+	}
+	return nil
+}
+
+// dateTime2 encodes a datetime2(7): the time in 100ns units on 5 bytes and the
+// days since 0001-01-01 on 3 bytes.
+func dateTime2(t time.Time) []byte {
+	const daysTo1970 = 719162
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	days := uint32(midnight.Unix()/86400 + daysTo1970)
+	ticks := uint64(t.Sub(midnight) / 100)
+
+	b := make([]byte, 8)
+	for i := 0; i < 5; i++ {
+		b[i] = byte(ticks >> (8 * uint(i)))
+	}
+	for i := 0; i < 3; i++ {
+		b[5+i] = byte(days >> (8 * uint(i)))
+	}
+	return b
+}
+
+// parseVersion parses a version like 15.0.2000.5 for the prelogin and the
+// login acknowledgement.
+func parseVersion(version string) (major, minor byte, build uint16) {
+	parts := strings.Split(version, ".")
+	n := make([]int, 3)
+	for i := 0; i < len(parts) && i < 3; i++ {
+		n[i], _ = strconv.Atoi(parts[i])
+	}
+	return byte(n[0]), byte(n[1]), uint16(n[2])
+}
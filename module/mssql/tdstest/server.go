@@ -0,0 +1,467 @@
+// Package tdstest implements a minimal SQL Server speaking the TDS protocol,
+// to test the connections of the module without a real server: the prelogin
+// and its TLS negotiation, SQL and NTLM logins, SQL batches answered with
+// recorded result sets or errors, timeouts and dropped connections.
+package tdstest
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+// Config describes the server.
+type Config struct {
+	// Logins are the SQL logins and their passwords.
+	Logins map[string]string
+	// WindowsLogins are the DOMAIN\user logins authenticated with NTLM, and
+	// their passwords.
+	WindowsLogins map[string]string
+
+	// TLS enables the encryption. Without it the server does not support
+	// encryption, with it the login is encrypted, and the whole connection
+	// when the client asks for it or ForceEncryption is set.
+	TLS             *tls.Config
+	ForceEncryption bool
+
+	// ServerName and Version are returned in the login acknowledgement and
+	// by the connection queries. They default to TDSTEST and 15.0.2000.5.
+	ServerName string
+	Version    string
+
+	// Results answer the SQL batches, matched like by a mssql.ReplayQuerier.
+	// Recorded errors are returned as error tokens.
+	Results *mssql.Recording
+
+	// LoginDelay delays the answer to the logins.
+	LoginDelay time.Duration
+	// QueryDelays delay the answer to the batches containing the keys,
+	// until the client cancels them.
+	QueryDelays map[string]time.Duration
+}
+
+// Server is a TDS server listening on a local port.
+type Server struct {
+	config  Config
+	addr    string
+	results *mssql.ReplayQuerier
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]bool
+	logins   []string
+	batches  []string
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server on a free port of the loopback interface.
+func NewServer(config Config) (*Server, error) {
+	if config.ServerName == "" {
+		config.ServerName = "TDSTEST"
+	}
+	if config.Version == "" {
+		config.Version = "15.0.2000.5"
+	}
+	s := &Server{config: config, conns: map[net.Conn]bool{}}
+	if config.Results != nil {
+		s.results = mssql.NewReplayQuerier(config.Results)
+	}
+	if err := s.listen("127.0.0.1:0"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Server) listen(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.listener = l
+	s.addr = l.Addr().String()
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.accept(l)
+	return nil
+}
+
+// Addr returns the host and port of the server.
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// URL returns the URL of the server for the given login, with the extra
+// connection parameters given as pairs of names and values.
+func (s *Server) URL(user, password string, params ...string) string {
+	u := url.URL{Scheme: "sqlserver", User: url.UserPassword(user, password), Host: s.Addr()}
+	q := url.Values{}
+	for i := 0; i+1 < len(params); i += 2 {
+		q.Set(params[i], params[i+1])
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// Logins returns the logins accepted by the server.
+func (s *Server) Logins() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.logins...)
+}
+
+// Batches returns the text of the SQL batches received by the server.
+func (s *Server) Batches() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.batches...)
+}
+
+// DropConnections closes the open connections, like a restart of the server
+// or a network failure.
+func (s *Server) DropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// Stop stops listening and drops the connections.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+	}
+	s.mu.Unlock()
+	s.DropConnections()
+}
+
+// Start listens again on the address of the stopped server.
+func (s *Server) Start() error {
+	return s.listen(s.Addr())
+}
+
+// Close stops the server and waits for its connections to end.
+func (s *Server) Close() {
+	s.Stop()
+	s.wg.Wait()
+}
+
+func (s *Server) accept(l net.Listener) {
+	defer s.wg.Done()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = true
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			c := &session{server: s, conn: conn}
+			c.serve()
+		}()
+	}
+}
+
+// session is a client connection.
+type session struct {
+	server *Server
+	conn   net.Conn
+
+	// rw carries the messages, through TLS when the connection is encrypted.
+	rw        net.Conn
+	encrypted bool
+	login     string
+	ntlm      bool
+}
+
+func (c *session) serve() {
+	if err := c.prelogin(); err != nil {
+		return
+	}
+	if !c.authenticate() {
+		return
+	}
+
+	s := c.server
+	s.mu.Lock()
+	s.logins = append(s.logins, c.login)
+	s.mu.Unlock()
+
+	// Attentions are read while the batches run.
+	messages := make(chan message)
+	go func() {
+		defer close(messages)
+		for {
+			t, payload, err := readMessage(c.rw)
+			if err != nil {
+				return
+			}
+			messages <- message{t, payload}
+		}
+	}()
+	defer func() {
+		c.conn.Close()
+		for range messages {
+		}
+	}()
+
+	for msg := range messages {
+		var r reply
+		switch msg.packetType {
+		case packetSQLBatch:
+			batch, err := parseSQLBatch(msg.payload)
+			if err != nil {
+				return
+			}
+			if !c.batch(batch, &r, messages) {
+				r.Reset()
+				r.done(doneAttn, 0, 0)
+			}
+		case packetAttention:
+			r.done(doneAttn, 0, 0)
+		default:
+			r.error(s.config.ServerName, Error{Number: 50000, Class: 16, Message: fmt.Sprintf("tdstest: unsupported message type %d", msg.packetType)})
+			r.done(doneError, 0, 0)
+		}
+		if err := writeMessage(c.rw, packetReply, r.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+type message struct {
+	packetType byte
+	payload    []byte
+}
+
+// prelogin negotiates the encryption and runs the TLS handshake.
+func (c *session) prelogin() error {
+	t, payload, err := readMessage(c.conn)
+	if err != nil {
+		return err
+	}
+	if t != packetPrelogin {
+		return fmt.Errorf("unexpected message type %d", t)
+	}
+	options, err := parsePrelogin(payload)
+	if err != nil {
+		return err
+	}
+	clientEncrypt := byte(encryptOff)
+	if v := options[preloginEncryption]; len(v) > 0 {
+		clientEncrypt = v[0]
+	}
+
+	config := c.server.config
+	encrypt := byte(encryptNotSup)
+	switch {
+	case config.TLS == nil:
+	case clientEncrypt == encryptNotSup && config.ForceEncryption:
+		return fmt.Errorf("encryption required")
+	case clientEncrypt == encryptNotSup:
+	case clientEncrypt == encryptOn, clientEncrypt == encryptReq:
+		encrypt = encryptOn
+	case config.ForceEncryption:
+		encrypt = encryptReq
+	default:
+		encrypt = encryptOff
+	}
+
+	major, minor, build := parseVersion(config.Version)
+	err = writeMessage(c.conn, packetReply, buildPrelogin(map[byte][]byte{
+		preloginVersion:    {major, minor, byte(build >> 8), byte(build), 0, 0},
+		preloginEncryption: {encrypt},
+		preloginInstance:   {0},
+		preloginThreadID:   {},
+		preloginMARS:       {0},
+	}))
+	if err != nil {
+		return err
+	}
+
+	c.rw = c.conn
+	if encrypt == encryptNotSup {
+		return nil
+	}
+	tlsConn, err := serverTLS(c.conn, config.TLS)
+	if err != nil {
+		return err
+	}
+	c.rw = tlsConn
+	c.encrypted = encrypt != encryptOff
+	return nil
+}
+
+// authenticate reads the login and answers it. It returns whether the login
+// succeeded.
+func (c *session) authenticate() bool {
+	t, payload, err := readMessage(c.rw)
+	if err != nil || t != packetLogin7 {
+		return false
+	}
+	if !c.encrypted {
+		// Only the login was encrypted.
+		c.rw = c.conn
+	}
+	l, err := parseLogin7(payload)
+	if err != nil {
+		return false
+	}
+	time.Sleep(c.server.config.LoginDelay)
+
+	config := c.server.config
+	var r reply
+	ok := false
+	if l.integratedSecurity {
+		c.ntlm = true
+		msg, challenge := ntlmChallenge()
+		r.sspi(msg)
+		if err := writeMessage(c.rw, packetReply, r.Bytes()); err != nil {
+			return false
+		}
+		r.Reset()
+
+		t, payload, err := readMessage(c.rw)
+		if err != nil || t != packetSSPI {
+			return false
+		}
+		c.login, err = ntlmAuthenticate(payload, challenge, config.WindowsLogins)
+		ok = err == nil
+	} else {
+		c.login = l.userName
+		password, found := config.Logins[l.userName]
+		ok = found && password == l.password
+	}
+
+	if !ok {
+		r.error(config.ServerName, Error{Number: 18456, State: 1, Class: 14, Message: fmt.Sprintf("Login failed for user '%s'.", c.login)})
+		r.done(doneError, 0, 0)
+		writeMessage(c.rw, packetReply, r.Bytes())
+		return false
+	}
+
+	database := l.database
+	if database == "" {
+		database = "master"
+	}
+	major, minor, build := parseVersion(config.Version)
+	r.envChange(1, database, "master")
+	r.envChange(4, strconv.Itoa(packetSize), strconv.Itoa(packetSize))
+	r.loginAck([4]byte{major, minor, byte(build >> 8), byte(build)})
+	r.done(doneFinal, 0, 0)
+	return writeMessage(c.rw, packetReply, r.Bytes()) == nil
+}
+
+// batch answers a SQL batch. It returns false when the client cancelled it
+// with an attention.
+func (c *session) batch(batch string, r *reply, messages <-chan message) bool {
+	s := c.server
+	s.mu.Lock()
+	s.batches = append(s.batches, batch)
+	s.mu.Unlock()
+
+	for key, delay := range s.config.QueryDelays {
+		if !strings.Contains(batch, key) {
+			continue
+		}
+		select {
+		case <-time.After(delay):
+		case msg, ok := <-messages:
+			if !ok || msg.packetType == packetAttention {
+				return false
+			}
+		}
+	}
+
+	set, err := c.result(batch)
+	if err != nil {
+		r.error(s.config.ServerName, Error{Number: 50000, Class: 16, Message: err.Error()})
+		r.done(doneError, 0, 0)
+		return true
+	}
+	if set.Error != nil {
+		number := set.Error.Number
+		if number == 0 {
+			number = 50000
+		}
+		r.error(s.config.ServerName, Error{Number: number, Class: 16, Message: set.Error.Message})
+		r.done(doneError, 0, 0)
+		return true
+	}
+
+	types := make([]byte, len(set.Columns))
+	if len(types) == 0 && len(set.Rows) > 0 {
+		types = make([]byte, len(set.Rows[0]))
+	}
+	for i := range types {
+		types[i] = columnType(set.Rows, i)
+	}
+	r.colMetadata(set.Columns, types)
+	for _, row := range set.Rows {
+		if err := r.row(types, row); err != nil {
+			r.Reset()
+			r.error(s.config.ServerName, Error{Number: 50000, Class: 16, Message: "tdstest: " + err.Error()})
+			r.done(doneError, 0, 0)
+			return true
+		}
+	}
+	r.done(doneCount, curCmdSelect, uint64(len(set.Rows)))
+	return true
+}
+
+// result returns the result set of a batch. The queries describing the
+// connection are answered by the server itself.
+func (c *session) result(batch string) (*mssql.ResultSet, error) {
+	config := c.server.config
+	normalized := strings.ToLower(strings.Join(strings.Fields(batch), " "))
+	switch {
+	case normalized == "select 1" || normalized == "select 1;":
+		return &mssql.ResultSet{Columns: []string{""}, Rows: [][]interface{}{{1}}}, nil
+	case strings.Contains(batch, "CONNECTIONPROPERTY('net_transport')"):
+		port := c.conn.LocalAddr().(*net.TCPAddr).Port
+		scheme := "SQL"
+		if c.ntlm {
+			scheme = "NTLM"
+		}
+		return &mssql.ResultSet{
+			Columns: []string{"", "", "", "", "", ""},
+			Rows:    [][]interface{}{{config.ServerName, config.Version, "Developer Edition (64-bit)", "TCP", port, scheme}},
+		}, nil
+	case strings.Contains(batch, "encrypt_option"):
+		encryption := "not encrypted"
+		if c.encrypted {
+			encryption = "encrypted"
+		}
+		return &mssql.ResultSet{Columns: []string{""}, Rows: [][]interface{}{{encryption}}}, nil
+	}
+
+	if c.server.results == nil {
+		return nil, fmt.Errorf("tdstest: no result for %s", batch)
+	}
+	return c.server.results.Next(batch)
+}
@@ -0,0 +1,224 @@
+// +build !integration
+
+package tdstest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/denisenkom/go-mssqldb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func newServer(t *testing.T, config Config) *Server {
+	if config.Logins == nil {
+		config.Logins = map[string]string{"beat": "secret"}
+	}
+	s, err := NewServer(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func query(url, q string, dest ...interface{}) error {
+	db, err := sql.Open("sqlserver", url)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.QueryRow(q).Scan(dest...)
+}
+
+// queryEncrypted is query without closing the connection pool: the driver
+// panics when closing an encrypted connection with Go 1.12 and later, its
+// connection does not support deadlines. The server closes the connection.
+func queryEncrypted(url, q string, dest ...interface{}) error {
+	db, err := sql.Open("sqlserver", url)
+	if err != nil {
+		return err
+	}
+	return db.QueryRow(q).Scan(dest...)
+}
+
+func TestLogin(t *testing.T) {
+	s := newServer(t, Config{WindowsLogins: map[string]string{`CORP\beat`: "windows"}})
+	defer s.Close()
+
+	tests := []struct {
+		user, password string
+		class          string
+	}{
+		{"beat", "secret", ""},
+		{"beat", "wrong", mssql.ErrorClassLogin},
+		{"unknown", "secret", mssql.ErrorClassLogin},
+		{`CORP\beat`, "windows", ""},
+		{`CORP\beat`, "wrong", mssql.ErrorClassLogin},
+	}
+	for _, test := range tests {
+		var one int
+		err := query(s.URL(test.user, test.password), "SELECT 1", &one)
+		if class := mssql.ErrorClass(nil, err); class != test.class {
+			t.Errorf("login %s/%s: expected error class %q, got %q (%v)", test.user, test.password, test.class, class, err)
+		}
+		if err == nil && one != 1 {
+			t.Errorf("login %s: expected 1, got %d", test.user, one)
+		}
+	}
+
+	logins := s.Logins()
+	if len(logins) != 2 || logins[0] != "beat" || logins[1] != `CORP\beat` {
+		t.Errorf("unexpected accepted logins %v", logins)
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	config, cert, err := SelfSignedTLS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "tdstest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "cert.pem")
+	if err := ioutil.WriteFile(certFile, cert, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		config     Config
+		params     []string
+		encryption string
+		err        string
+	}{
+		{"not supported", Config{}, nil, "not encrypted", ""},
+		{"login only", Config{TLS: config}, []string{"certificate", certFile}, "not encrypted", ""},
+		{"requested", Config{TLS: config}, []string{"encrypt", "true", "certificate", certFile}, "encrypted", ""},
+		{"forced", Config{TLS: config, ForceEncryption: true}, []string{"certificate", certFile}, "encrypted", ""},
+		{"not trusted", Config{TLS: config}, []string{"encrypt", "true"}, "", mssql.ErrorClassTLS},
+		{"required by the client", Config{}, []string{"encrypt", "true"}, "", mssql.ErrorClassTLS},
+		{"disabled by the client", Config{TLS: config, ForceEncryption: true}, []string{"encrypt", "disable"}, "", mssql.ErrorClassUnknown},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newServer(t, test.config)
+			defer s.Close()
+
+			var encryption string
+			err := queryEncrypted(s.URL("beat", "secret", test.params...), "SELECT encrypt_option FROM sys.dm_exec_connections", &encryption)
+			if class := mssql.ErrorClass(nil, err); class != test.err {
+				t.Fatalf("expected error class %q, got %q (%v)", test.err, class, err)
+			}
+			if encryption != test.encryption {
+				t.Errorf("expected %q, got %q", test.encryption, encryption)
+			}
+		})
+	}
+}
+
+func TestResults(t *testing.T) {
+	s := newServer(t, Config{Results: &mssql.Recording{ResultSets: []mssql.ResultSet{
+		{Match: "FROM t", Columns: []string{"i", "f", "s", "b", "d"}, Rows: [][]interface{}{
+			{json.Number("1"), json.Number("1.5"), "x", true, "2019-06-01T10:00:00.1234567Z"},
+			{nil, nil, nil, nil, nil},
+		}},
+		{Match: "FROM denied", Error: &mssql.RecordedError{Number: 229, Message: "The SELECT permission was denied."}},
+	}}})
+	defer s.Close()
+
+	db, err := sql.Open("sqlserver", s.URL("beat", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT i, f, s, b, d FROM t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]interface{}
+	for rows.Next() {
+		var i sql.NullInt64
+		var f sql.NullFloat64
+		var s sql.NullString
+		var b sql.NullBool
+		var d *time.Time
+		if err := rows.Scan(&i, &f, &s, &b, &d); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, []interface{}{i, f, s, b, d})
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(got))
+	}
+	first := got[0]
+	d := first[4].(*time.Time)
+	if first[0].(sql.NullInt64).Int64 != 1 || first[1].(sql.NullFloat64).Float64 != 1.5 ||
+		first[2].(sql.NullString).String != "x" || !first[3].(sql.NullBool).Bool ||
+		d == nil || !d.Equal(time.Date(2019, 6, 1, 10, 0, 0, 123456700, time.UTC)) {
+		t.Errorf("unexpected first row %v", first)
+	}
+	if got[1][0].(sql.NullInt64).Valid || got[1][2].(sql.NullString).Valid || got[1][4].(*time.Time) != nil {
+		t.Errorf("expected NULL values, got %v", got[1])
+	}
+
+	var one int
+	err = db.QueryRow("SELECT 1 FROM denied").Scan(&one)
+	if class := mssql.ErrorClass(nil, err); class != mssql.ErrorClassQuery || !mssql.IsPermissionDenied(err) {
+		t.Errorf("expected a permission error, got %v", err)
+	}
+	err = db.QueryRow("SELECT 1 FROM unknown").Scan(&one)
+	if err == nil || !strings.Contains(err.Error(), "no recorded result") {
+		t.Errorf("expected an error for an unknown query, got %v", err)
+	}
+	if batches := s.Batches(); len(batches) != 3 {
+		t.Errorf("expected 3 batches, got %v", batches)
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	s := newServer(t, Config{
+		LoginDelay:  1500 * time.Millisecond,
+		QueryDelays: map[string]time.Duration{"WAITFOR": time.Minute},
+	})
+	defer s.Close()
+
+	var one int
+	err := query(s.URL("beat", "secret", "connection timeout", "1"), "SELECT 1", &one)
+	if class := mssql.ErrorClass(nil, err); class != mssql.ErrorClassTimeout {
+		t.Errorf("expected a login timeout, got %q (%v)", class, err)
+	}
+
+	db, err := sql.Open("sqlserver", s.URL("beat", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err = db.QueryRowContext(ctx, "WAITFOR DELAY '00:01'").Scan(&one)
+	if class := mssql.ErrorClass(ctx, err); class != mssql.ErrorClassTimeout {
+		t.Errorf("expected a query timeout, got %q (%v)", class, err)
+	}
+
+	// The cancelled query does not break the connection.
+	if err := db.QueryRow("SELECT 1").Scan(&one); err != nil {
+		t.Errorf("query after a timeout failed: %v", err)
+	}
+}
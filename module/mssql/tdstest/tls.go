@@ -0,0 +1,111 @@
+package tdstest
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+)
+
+// SelfSignedTLS returns a TLS configuration with a certificate for localhost
+// and 127.0.0.1, and the certificate in PEM format, to be given to the
+// clients in the certificate parameter.
+func SelfSignedTLS() (*tls.Config, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+	}
+	return config, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// handshakeConn carries the TLS handshake in prelogin messages, like SQL
+// Server does. Once the handshake is done the records go on the connection
+// as they are.
+type handshakeConn struct {
+	net.Conn
+	done    bool
+	pending bytes.Buffer
+	payload []byte
+}
+
+func (c *handshakeConn) Read(b []byte) (int, error) {
+	if c.done {
+		return c.Conn.Read(b)
+	}
+	if err := c.flush(); err != nil {
+		return 0, err
+	}
+	for len(c.payload) == 0 {
+		_, payload, err := readMessage(c.Conn)
+		if err != nil {
+			return 0, err
+		}
+		c.payload = payload
+	}
+	n := copy(b, c.payload)
+	c.payload = c.payload[n:]
+	return n, nil
+}
+
+func (c *handshakeConn) Write(b []byte) (int, error) {
+	if c.done {
+		return c.Conn.Write(b)
+	}
+	return c.pending.Write(b)
+}
+
+// flush sends the pending handshake records in a prelogin message.
+func (c *handshakeConn) flush() error {
+	if c.pending.Len() == 0 {
+		return nil
+	}
+	err := writeMessage(c.Conn, packetPrelogin, c.pending.Bytes())
+	c.pending.Reset()
+	return err
+}
+
+// serverTLS runs the TLS handshake of a connection. The clients of the TDS
+// protocol expect the server to finish the handshake, which is only the
+// case up to TLS 1.2.
+func serverTLS(conn net.Conn, config *tls.Config) (*tls.Conn, error) {
+	config = config.Clone()
+	config.MaxVersion = tls.VersionTLS12
+
+	hc := &handshakeConn{Conn: conn}
+	tlsConn := tls.Server(hc, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	if err := hc.flush(); err != nil {
+		return nil, err
+	}
+	hc.done = true
+	return tlsConn, nil
+}
@@ -0,0 +1,258 @@
+package tempdb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "tempdb", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("tempdb", fields)
+	mssql.RequirePermissions("tempdb", mssql.ViewServerState)
+}
+
+// Space of the tempdb data files by use, in pages of 8 KB.
+const spaceQuery = `
+	SELECT
+		SUM(total_page_count),
+		SUM(unallocated_extent_page_count),
+		SUM(user_object_reserved_page_count),
+		SUM(internal_object_reserved_page_count),
+		SUM(version_store_reserved_page_count),
+		SUM(mixed_extent_page_count)
+	FROM tempdb.sys.dm_db_file_space_usage
+`
+
+// The @p1 sessions using the most tempdb pages. sys.dm_db_session_space_usage
+// counts the pages of the completed tasks of a session, and
+// sys.dm_db_task_space_usage those of its running tasks.
+const sessionsQuery = `
+	SELECT TOP (@p1)
+		s.session_id, s.login_name, s.host_name, s.program_name, s.status,
+		u.user_pages, u.internal_pages
+	FROM (
+		SELECT
+			ss.session_id,
+			ss.user_objects_alloc_page_count - ss.user_objects_dealloc_page_count
+				+ ISNULL(ts.user_alloc, 0) - ISNULL(ts.user_dealloc, 0) AS user_pages,
+			ss.internal_objects_alloc_page_count - ss.internal_objects_dealloc_page_count
+				+ ISNULL(ts.internal_alloc, 0) - ISNULL(ts.internal_dealloc, 0) AS internal_pages
+		FROM sys.dm_db_session_space_usage AS ss
+		LEFT JOIN (
+			SELECT
+				session_id,
+				SUM(user_objects_alloc_page_count) AS user_alloc,
+				SUM(user_objects_dealloc_page_count) AS user_dealloc,
+				SUM(internal_objects_alloc_page_count) AS internal_alloc,
+				SUM(internal_objects_dealloc_page_count) AS internal_dealloc
+			FROM sys.dm_db_task_space_usage
+			GROUP BY session_id
+		) AS ts ON ts.session_id = ss.session_id
+	) AS u
+	JOIN sys.dm_exec_sessions AS s ON s.session_id = u.session_id
+	WHERE u.user_pages + u.internal_pages > 0
+	ORDER BY u.user_pages + u.internal_pages DESC
+`
+
+// Version store space per database, from SQL Server 2017.
+const versionStoreQuery = `
+	SELECT DB_NAME(database_id), reserved_space_kb
+	FROM sys.dm_tran_version_store_space_usage
+	WHERE reserved_page_count > 0
+`
+
+var fields = mssql.Field{
+	Name:        "tempdb",
+	Type:        "group",
+	Description: "`tempdb` contains the space used in tempdb, by a session, or by the version store of a database.",
+	Fields: []mssql.Field{
+		{Name: "space.total.kb", Type: "long", Description: "Size of the tempdb data files."},
+		{Name: "space.free.kb", Type: "long", Description: "Free space in the tempdb data files."},
+		{Name: "space.user_objects.kb", Type: "long", Description: "Space used by user objects, such as temporary tables and table variables."},
+		{Name: "space.internal_objects.kb", Type: "long", Description: "Space used by internal objects, such as the work tables of sorts, hash joins and spools."},
+		{Name: "space.version_store.kb", Type: "long", Description: "Space used by the version store, the row versions of snapshot isolation, online index operations and triggers."},
+		{Name: "space.mixed_extents.kb", Type: "long", Description: "Space of the mixed extents, shared by several objects."},
+		{Name: "session.id", Type: "integer", Description: "Id of the session."},
+		{Name: "session.login", Type: "keyword", Description: "Login of the session."},
+		{Name: "session.host", Type: "keyword", Description: "Name of the client machine of the session."},
+		{Name: "session.program", Type: "keyword", Description: "Name of the client program of the session."},
+		{Name: "session.status", Type: "keyword", Description: "Status of the session."},
+		{Name: "session.user_objects.kb", Type: "long", Description: "Space used by the user objects of the session."},
+		{Name: "session.internal_objects.kb", Type: "long", Description: "Space used by the internal objects of the session."},
+		{Name: "session.total.kb", Type: "long", Description: "Space used by the session."},
+		{Name: "version_store.kb", Type: "long", Description: "Space used in the version store by the row versions of the database. From SQL Server 2017."},
+	},
+}
+
+// pageKB is the size of a page.
+const pageKB = 8
+
+type space struct {
+	total, free, userObjects, internalObjects, versionStore, mixedExtents int64
+}
+
+type session struct {
+	id                       int64
+	login, status            string
+	host, program            sql.NullString
+	userPages, internalPages int64
+}
+
+type versionStore struct {
+	database string
+	kb       int64
+}
+
+// MetricSet reports the tempdb space by use, the sessions using the most of
+// it, and the version store of every database.
+type MetricSet struct {
+	*mssql.MetricSet
+	top int
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		Top int `config:"tempdb.sessions.top" validate:"min=0"`
+	}{10}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, top: config.Top}, nil
+}
+
+// Fetch reports an event with the tempdb space by use, one event per session
+// among the tempdb.sessions.top using the most space, and from SQL Server
+// 2017 one event per database with row versions in the version store.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	var s space
+	err := m.Query(ctx, spaceQuery, func(rows mssql.Rows) error {
+		return rows.Scan(&s.total, &s.free, &s.userObjects, &s.internalObjects, &s.versionStore, &s.mixedExtents)
+	})
+	if err != nil {
+		return err
+	}
+	if !r.Event(mb.Event{MetricSetFields: s.fields()}) {
+		return nil
+	}
+
+	if m.top > 0 {
+		sessions, err := m.querySessions(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			if !r.Event(mb.Event{MetricSetFields: s.fields()}) {
+				return nil
+			}
+		}
+	}
+
+	// sys.dm_tran_version_store_space_usage appeared in SQL Server 2017.
+	if !m.Instance.Version().AtLeast(14, 0, 0) {
+		return nil
+	}
+	stores, err := m.queryVersionStores(ctx)
+	if err != nil {
+		return err
+	}
+	for _, v := range stores {
+		event := mb.Event{
+			ModuleFields: common.MapStr{
+				"database": common.MapStr{
+					"name": v.database,
+				},
+			},
+			MetricSetFields: common.MapStr{
+				"version_store": common.MapStr{"kb": v.kb},
+			},
+		}
+		if !r.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s space) fields() common.MapStr {
+	return common.MapStr{
+		"space": common.MapStr{
+			"total":            common.MapStr{"kb": s.total * pageKB},
+			"free":             common.MapStr{"kb": s.free * pageKB},
+			"user_objects":     common.MapStr{"kb": s.userObjects * pageKB},
+			"internal_objects": common.MapStr{"kb": s.internalObjects * pageKB},
+			"version_store":    common.MapStr{"kb": s.versionStore * pageKB},
+			"mixed_extents":    common.MapStr{"kb": s.mixedExtents * pageKB},
+		},
+	}
+}
+
+func (s session) fields() common.MapStr {
+	fields := common.MapStr{
+		"id":               s.id,
+		"login":            s.login,
+		"status":           s.status,
+		"user_objects":     common.MapStr{"kb": s.userPages * pageKB},
+		"internal_objects": common.MapStr{"kb": s.internalPages * pageKB},
+		"total":            common.MapStr{"kb": (s.userPages + s.internalPages) * pageKB},
+	}
+	if s.host.Valid {
+		fields.Put("host", s.host.String)
+	}
+	if s.program.Valid {
+		fields.Put("program", s.program.String)
+	}
+	return common.MapStr{"session": fields}
+}
+
+func (m *MetricSet) querySessions(ctx context.Context) ([]session, error) {
+	var sessions []session
+	err := m.Query(ctx, sessionsQuery, func(rows mssql.Rows) error {
+		var s session
+		if err := rows.Scan(&s.id, &s.login, &s.host, &s.program, &s.status, &s.userPages, &s.internalPages); err != nil {
+			return err
+		}
+		sessions = append(sessions, s)
+		return nil
+	}, m.top)
+
+	return sessions, err
+}
+
+func (m *MetricSet) queryVersionStores(ctx context.Context) ([]versionStore, error) {
+	var stores []versionStore
+	err := m.Query(ctx, versionStoreQuery, func(rows mssql.Rows) error {
+		var database sql.NullString
+		var v versionStore
+		if err := rows.Scan(&database, &v.kb); err != nil {
+			return err
+		}
+		// Versions of a database dropped since they were read.
+		if !database.Valid {
+			return nil
+		}
+		v.database = database.String
+		stores = append(stores, v)
+		return nil
+	})
+
+	return stores, err
+}
@@ -0,0 +1,20 @@
+// +build !integration
+
+package tempdb
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestSessionTotal(t *testing.T) {
+	se := session{
+		id: 112, login: "etl", status: "running",
+		host:      sql.NullString{String: "ETL01", Valid: true},
+		program:   sql.NullString{String: "SSIS-LoadFacts", Valid: true},
+		userPages: 40960, internalPages: 98304,
+	}
+	if total, _ := se.fields().GetValue("session.total.kb"); total != int64(1114112) {
+		t.Errorf("expected a total of 1114112 KB, got %v", total)
+	}
+}
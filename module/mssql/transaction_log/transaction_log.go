@@ -0,0 +1,269 @@
+package transaction_log
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "transaction_log", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("transaction_log", fields)
+	mssql.RequirePermissions("transaction_log", mssql.ViewServerState)
+}
+
+// Per database log counters of the Databases performance object. The
+// instance name of these counters is the database name.
+const query = `
+	SELECT RTRIM(instance_name), RTRIM(counter_name), cntr_value
+	FROM sys.dm_os_performance_counters
+	WHERE object_name LIKE '%:Databases%'
+	AND instance_name <> '_Total'
+	AND counter_name IN (
+		'Log File(s) Size (KB)', 'Log File(s) Used Size (KB)', 'Percent Log Used',
+		'Log Growths', 'Log Shrinks', 'Log Truncations'
+	)
+`
+
+// Recovery model of the online databases, and what prevents their log from
+// being truncated.
+const databasesQuery = `
+	SELECT name, recovery_model_desc, log_reuse_wait_desc
+	FROM sys.databases
+	WHERE state = 0
+`
+
+// Log usage since the last backup and checkpoint, from SQL Server 2016 SP2.
+// The sizes are converted from MB to KB.
+const logStatsQuery = `
+	SELECT
+		d.name,
+		CAST(ls.active_log_size_mb * 1024 AS bigint),
+		CAST(ls.log_since_last_log_backup_mb * 1024 AS bigint),
+		CAST(ls.log_since_last_checkpoint_mb * 1024 AS bigint),
+		ls.log_truncation_holdup_reason
+	FROM sys.databases AS d
+	CROSS APPLY sys.dm_db_log_stats(d.database_id) AS ls
+	WHERE d.state = 0
+`
+
+// Virtual log files of the online databases, from SQL Server 2016 SP2. A log
+// grown in many small increments has thousands of them, which slows down
+// the recovery and the log backups.
+const vlfQuery = `
+	SELECT d.name, COUNT(*), SUM(CAST(li.vlf_active AS int))
+	FROM sys.databases AS d
+	CROSS APPLY sys.dm_db_log_info(d.database_id) AS li
+	WHERE d.state = 0
+	GROUP BY d.name
+`
+
+// Age of the oldest transaction that wrote to the log of each database, the
+// log cannot be truncated past its first record.
+const oldestTransactionQuery = `
+	SELECT DB_NAME(database_id), DATEDIFF(SECOND, MIN(database_transaction_begin_time), GETDATE())
+	FROM sys.dm_tran_database_transactions
+	WHERE database_transaction_begin_time IS NOT NULL
+	GROUP BY database_id
+`
+
+// fieldsByCounter maps the counter names to event fields.
+var fieldsByCounter = map[string]string{
+	"log file(s) size (kb)":      "size.kb",
+	"log file(s) used size (kb)": "used.kb",
+	"percent log used":           "used.pct",
+	"log growths":                "growths",
+	"log shrinks":                "shrinks",
+	"log truncations":            "truncations",
+}
+
+var fields = mssql.Field{
+	Name:        "transaction_log",
+	Type:        "group",
+	Description: "`transaction_log` contains the transaction log usage of a database.",
+	Fields: []mssql.Field{
+		{Name: "size.kb", Type: "long", Description: "Total size of the log files."},
+		{Name: "used.kb", Type: "long", Description: "Space used in the log files."},
+		{Name: "used.pct", Type: "scaled_float", Format: "percent", Description: "Percentage of the log space in use."},
+		{Name: "growths", Type: "long", Description: "Number of times the log was expanded since the database started."},
+		{Name: "shrinks", Type: "long", Description: "Number of times the log was shrunk since the database started."},
+		{Name: "truncations", Type: "long", Description: "Number of times the log was truncated since the database started."},
+		{Name: "recovery_model", Type: "keyword", Description: "Recovery model of the database: FULL, BULK_LOGGED or SIMPLE."},
+		{Name: "reuse_wait", Type: "keyword", Description: "What prevents the log from being truncated, for example LOG_BACKUP or ACTIVE_TRANSACTION. NOTHING when it can be truncated."},
+		{Name: "active.kb", Type: "long", Description: "Size of the active part of the log, which cannot be truncated. From SQL Server 2016 SP2."},
+		{Name: "since_last_backup.kb", Type: "long", Description: "Log written since the last log backup. From SQL Server 2016 SP2."},
+		{Name: "since_last_checkpoint.kb", Type: "long", Description: "Log written since the last checkpoint. From SQL Server 2016 SP2."},
+		{Name: "truncation_holdup", Type: "keyword", Description: "What prevents the log from being truncated, as reported by sys.dm_db_log_stats. From SQL Server 2016 SP2."},
+		{Name: "vlfs.count", Type: "long", Description: "Number of virtual log files. From SQL Server 2016 SP2."},
+		{Name: "vlfs.active", Type: "long", Description: "Number of active virtual log files. From SQL Server 2016 SP2."},
+		{Name: "oldest_transaction.age.sec", Type: "long", Description: "Age of the oldest open transaction that wrote to the log of the database."},
+	},
+}
+
+// MetricSet reports the transaction log usage of every database, and what
+// prevents it from being truncated.
+type MetricSet struct {
+	*mssql.MetricSet
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms}, nil
+}
+
+// Fetch reports one event per online database.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	logs := databaseLogs{}
+	if err := m.queryDatabases(ctx, logs); err != nil {
+		return err
+	}
+	if err := m.queryLogUsage(ctx, logs); err != nil {
+		return err
+	}
+	// sys.dm_db_log_stats and sys.dm_db_log_info appeared in SQL Server 2016
+	// SP2.
+	if m.Instance.Version().AtLeast(13, 0, 5026) {
+		if err := m.queryLogStats(ctx, logs); err != nil {
+			return err
+		}
+		if err := m.queryVLFs(ctx, logs); err != nil {
+			return err
+		}
+	}
+	if err := m.queryOldestTransactions(ctx, logs); err != nil {
+		return err
+	}
+
+	for database, values := range logs {
+		event := mb.Event{
+			ModuleFields: common.MapStr{
+				"database": common.MapStr{
+					"name": database,
+				},
+			},
+			MetricSetFields: eventFields(values),
+		}
+		if !r.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func eventFields(values map[string]interface{}) common.MapStr {
+	fields := common.MapStr{}
+	for key, value := range values {
+		fields.Put(key, value)
+	}
+	return fields
+}
+
+// databaseLogs are the values of the event fields of every online database,
+// as listed by databasesQuery.
+type databaseLogs map[string]map[string]interface{}
+
+// set sets a field of an online database. The values of the other databases,
+// like the hidden mssqlsystemresource or the offline ones still listed by the
+// performance counters, are ignored.
+func (l databaseLogs) set(database, field string, value interface{}) {
+	if values, found := l[database]; found {
+		values[field] = value
+	}
+}
+
+func (m *MetricSet) queryLogUsage(ctx context.Context, logs databaseLogs) error {
+	return m.Query(ctx, query, func(rows mssql.Rows) error {
+		var database, counter string
+		var value int64
+		if err := rows.Scan(&database, &counter, &value); err != nil {
+			return err
+		}
+
+		field, found := fieldsByCounter[strings.ToLower(counter)]
+		if !found {
+			return nil
+		}
+		if field == "used.pct" {
+			// The counter is an integer percentage.
+			logs.set(database, field, float64(value)/100)
+			return nil
+		}
+		logs.set(database, field, value)
+		return nil
+	})
+}
+
+func (m *MetricSet) queryDatabases(ctx context.Context, logs databaseLogs) error {
+	return m.Query(ctx, databasesQuery, func(rows mssql.Rows) error {
+		var database, recoveryModel, reuseWait string
+		if err := rows.Scan(&database, &recoveryModel, &reuseWait); err != nil {
+			return err
+		}
+		logs[database] = map[string]interface{}{
+			"recovery_model": recoveryModel,
+			"reuse_wait":     reuseWait,
+		}
+		return nil
+	})
+}
+
+func (m *MetricSet) queryLogStats(ctx context.Context, logs databaseLogs) error {
+	return m.Query(ctx, logStatsQuery, func(rows mssql.Rows) error {
+		var database, holdup string
+		var active, sinceBackup, sinceCheckpoint int64
+		if err := rows.Scan(&database, &active, &sinceBackup, &sinceCheckpoint, &holdup); err != nil {
+			return err
+		}
+		logs.set(database, "active.kb", active)
+		logs.set(database, "since_last_backup.kb", sinceBackup)
+		logs.set(database, "since_last_checkpoint.kb", sinceCheckpoint)
+		logs.set(database, "truncation_holdup", holdup)
+		return nil
+	})
+}
+
+func (m *MetricSet) queryVLFs(ctx context.Context, logs databaseLogs) error {
+	return m.Query(ctx, vlfQuery, func(rows mssql.Rows) error {
+		var database string
+		var count, active int64
+		if err := rows.Scan(&database, &count, &active); err != nil {
+			return err
+		}
+		logs.set(database, "vlfs.count", count)
+		logs.set(database, "vlfs.active", active)
+		return nil
+	})
+}
+
+func (m *MetricSet) queryOldestTransactions(ctx context.Context, logs databaseLogs) error {
+	return m.Query(ctx, oldestTransactionQuery, func(rows mssql.Rows) error {
+		var database sql.NullString
+		var age int64
+		if err := rows.Scan(&database, &age); err != nil {
+			return err
+		}
+		// Transactions of a database dropped since they were read.
+		if !database.Valid {
+			return nil
+		}
+		logs.set(database.String, "oldest_transaction.age.sec", age)
+		return nil
+	})
+}
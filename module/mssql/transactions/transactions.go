@@ -0,0 +1,194 @@
+package transactions
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "transactions", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("transactions", fields)
+	mssql.RequirePermissions("transactions", mssql.ViewServerState)
+}
+
+// Transactions of the sessions open for at least @p1 seconds, one row per
+// database they used, with the last statement sent by the session. The
+// transactions of the monitoring session are left out.
+const query = `
+	SELECT
+		st.session_id,
+		at.transaction_id,
+		at.name,
+		DATEDIFF(SECOND, at.transaction_begin_time, GETDATE()),
+		at.transaction_state,
+		st.is_user_transaction,
+		s.login_name,
+		s.host_name,
+		s.program_name,
+		s.status,
+		DB_NAME(dt.database_id),
+		dt.database_transaction_log_bytes_used,
+		dt.database_transaction_log_bytes_reserved,
+		LEFT(t.text, 4000)
+	FROM sys.dm_tran_session_transactions AS st
+	JOIN sys.dm_tran_active_transactions AS at ON at.transaction_id = st.transaction_id
+	JOIN sys.dm_exec_sessions AS s ON s.session_id = st.session_id
+	LEFT JOIN sys.dm_tran_database_transactions AS dt ON dt.transaction_id = st.transaction_id
+	LEFT JOIN sys.dm_exec_connections AS c ON c.session_id = st.session_id AND c.parent_connection_id IS NULL
+	OUTER APPLY sys.dm_exec_sql_text(c.most_recent_sql_handle) AS t
+	WHERE st.session_id <> @@SPID
+	AND DATEDIFF(SECOND, at.transaction_begin_time, GETDATE()) >= @p1
+`
+
+// states are the descriptions of the transaction_state values.
+var states = map[int64]string{
+	0: "not initialized",
+	1: "initialized",
+	2: "active",
+	3: "ended",
+	4: "commit initiated",
+	5: "prepared",
+	6: "committed",
+	7: "rolling back",
+	8: "rolled back",
+}
+
+var fields = mssql.Field{
+	Name:        "transactions",
+	Type:        "group",
+	Description: "`transactions` contains a transaction open for longer than transactions.min_duration, and its use of the log of a database.",
+	Fields: []mssql.Field{
+		{Name: "id", Type: "long", Description: "Id of the transaction."},
+		{Name: "name", Type: "keyword", Description: "Name of the transaction, user_transaction for the transactions without a name."},
+		{Name: "age.sec", Type: "long", Description: "Time since the transaction began."},
+		{Name: "state", Type: "keyword", Description: "State of the transaction, for example active or rolling back."},
+		{Name: "user_transaction", Type: "boolean", Description: "Whether the transaction was started by a user request, rather than implicitly by a statement."},
+		{Name: "session.id", Type: "integer", Description: "Id of the session running the transaction."},
+		{Name: "session.login", Type: "keyword", Description: "Login of the session."},
+		{Name: "session.host", Type: "keyword", Description: "Name of the client machine of the session."},
+		{Name: "session.program", Type: "keyword", Description: "Name of the client program of the session."},
+		{Name: "session.status", Type: "keyword", Description: "Status of the session. A sleeping session with an open transaction is waiting for its client."},
+		{Name: "log.used.bytes", Type: "long", Format: "bytes", Description: "Log written by the transaction in the database."},
+		{Name: "log.reserved.bytes", Type: "long", Format: "bytes", Description: "Log reserved by the transaction in the database, to roll it back."},
+		{Name: "last_statement", Type: "text", Description: "Last SQL batch sent by the session, truncated to 4000 characters."},
+	},
+}
+
+type transaction struct {
+	sessionID, id, age, state int64
+	name                      string
+	user                      bool
+	login, status             string
+	host, program             sql.NullString
+	database, statement       sql.NullString
+	logUsed, logReserved      sql.NullInt64
+}
+
+// MetricSet reports the transactions open for longer than
+// transactions.min_duration.
+type MetricSet struct {
+	*mssql.MetricSet
+	minDuration time.Duration
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := struct {
+		MinDuration time.Duration `config:"transactions.min_duration" validate:"min=0"`
+	}{time.Minute}
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, minDuration: config.MinDuration}, nil
+}
+
+// Fetch reports one event per database used by a transaction open for longer
+// than transactions.min_duration, or one event for a transaction that did not
+// use a database yet.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	var transactions []transaction
+	err := m.Query(ctx, query, func(rows mssql.Rows) error {
+		var t transaction
+		err := rows.Scan(&t.sessionID, &t.id, &t.name, &t.age, &t.state, &t.user,
+			&t.login, &t.host, &t.program, &t.status,
+			&t.database, &t.logUsed, &t.logReserved, &t.statement)
+		if err != nil {
+			return err
+		}
+		transactions = append(transactions, t)
+		return nil
+	}, int64(m.minDuration/time.Second))
+	if err != nil {
+		return err
+	}
+
+	for _, t := range transactions {
+		event := mb.Event{MetricSetFields: t.fields()}
+		if t.database.Valid {
+			event.ModuleFields = common.MapStr{
+				"database": common.MapStr{
+					"name": t.database.String,
+				},
+			}
+		}
+		if !r.Event(event) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (t transaction) fields() common.MapStr {
+	state, found := states[t.state]
+	if !found {
+		state = strconv.FormatInt(t.state, 10)
+	}
+	session := common.MapStr{
+		"id":     t.sessionID,
+		"login":  t.login,
+		"status": t.status,
+	}
+	if t.host.Valid {
+		session.Put("host", t.host.String)
+	}
+	if t.program.Valid {
+		session.Put("program", t.program.String)
+	}
+
+	fields := common.MapStr{
+		"id":               t.id,
+		"name":             t.name,
+		"age":              common.MapStr{"sec": t.age},
+		"state":            state,
+		"user_transaction": t.user,
+		"session":          session,
+	}
+	if t.logUsed.Valid {
+		fields.Put("log.used.bytes", t.logUsed.Int64)
+		fields.Put("log.reserved.bytes", t.logReserved.Int64)
+	}
+	if t.statement.Valid {
+		fields.Put("last_statement", t.statement.String)
+	}
+	return fields
+}
@@ -0,0 +1,23 @@
+// +build !integration
+
+package transactions
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestState(t *testing.T) {
+	tr := transaction{
+		sessionID: 87, id: 102211304, name: "user_transaction", age: 4210, state: 2, user: true,
+		login: "beat", status: "sleeping",
+		host:        sql.NullString{String: "APPSRV01", Valid: true},
+		program:     sql.NullString{String: "OrdersService", Valid: true},
+		logUsed:     sql.NullInt64{Int64: 1024, Valid: true},
+		logReserved: sql.NullInt64{Int64: 2048, Valid: true},
+		statement:   sql.NullString{String: "UPDATE dbo.Orders SET Status = 3", Valid: true},
+	}
+	if state, _ := tr.fields().GetValue("state"); state != "active" {
+		t.Errorf("expected the active state, got %v", state)
+	}
+}
@@ -0,0 +1,198 @@
+package waits
+
+import (
+	"context"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/metricbeat/mb"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+	"github.com/mathenning/mssqlbeat/prometheus"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(mssql.ModuleName, "waits", New,
+		mb.WithHostParser(mssql.ParseURL),
+		mb.DefaultMetricSet(),
+	)
+	mssql.RegisterFields("waits", fields)
+	mssql.RequirePermissions("waits", mssql.ViewServerState)
+}
+
+// Wait statistics, without the wait types that accumulate while SQL Server is
+// idle and only add noise.
+const query = `
+	SELECT wait_type, waiting_tasks_count, wait_time_ms, max_wait_time_ms, signal_wait_time_ms
+	FROM sys.dm_os_wait_stats
+	WHERE wait_time_ms > 0
+	AND wait_type NOT IN (
+		'BROKER_EVENTHANDLER', 'BROKER_RECEIVE_WAITFOR', 'BROKER_TASK_STOP', 'BROKER_TO_FLUSH',
+		'BROKER_TRANSMITTER', 'CHECKPOINT_QUEUE', 'CHKPT', 'CLR_AUTO_EVENT', 'CLR_MANUAL_EVENT',
+		'CLR_SEMAPHORE', 'DBMIRROR_DBM_EVENT', 'DBMIRROR_EVENTS_QUEUE', 'DBMIRROR_WORKER_QUEUE',
+		'DBMIRRORING_CMD', 'DIRTY_PAGE_POLL', 'DISPATCHER_QUEUE_SEMAPHORE', 'EXECSYNC', 'FSAGENT',
+		'FT_IFTS_SCHEDULER_IDLE_WAIT', 'FT_IFTSHC_MUTEX', 'HADR_CLUSAPI_CALL',
+		'HADR_FILESTREAM_IOMGR_IOCOMPLETION', 'HADR_LOGCAPTURE_WAIT', 'HADR_NOTIFICATION_DEQUEUE',
+		'HADR_TIMER_TASK', 'HADR_WORK_QUEUE', 'KSOURCE_WAKEUP', 'LAZYWRITER_SLEEP', 'LOGMGR_QUEUE',
+		'MEMORY_ALLOCATION_EXT', 'ONDEMAND_TASK_QUEUE', 'PARALLEL_REDO_DRAIN_WORKER',
+		'PARALLEL_REDO_LOG_CACHE', 'PARALLEL_REDO_TRAN_LIST', 'PARALLEL_REDO_WORKER_SYNC',
+		'PARALLEL_REDO_WORKER_WAIT_WORK', 'PREEMPTIVE_XE_GETTARGETSTATE',
+		'PWAIT_ALL_COMPONENTS_INITIALIZED', 'PWAIT_DIRECTLOGCONSUMER_GETNEXT',
+		'QDS_PERSIST_TASK_MAIN_LOOP_SLEEP', 'QDS_ASYNC_QUEUE', 'QDS_CLEANUP_STALE_QUERIES_TASK_MAIN_LOOP_SLEEP',
+		'QDS_SHUTDOWN_QUEUE', 'REDO_THREAD_PENDING_WORK', 'REQUEST_FOR_DEADLOCK_SEARCH',
+		'RESOURCE_QUEUE', 'SERVER_IDLE_CHECK', 'SLEEP_BPOOL_FLUSH', 'SLEEP_DBSTARTUP',
+		'SLEEP_DCOMSTARTUP', 'SLEEP_MASTERDBREADY', 'SLEEP_MASTERMDREADY', 'SLEEP_MASTERUPGRADED',
+		'SLEEP_MSDBSTARTUP', 'SLEEP_SYSTEMTASK', 'SLEEP_TASK', 'SLEEP_TEMPDBSTARTUP',
+		'SNI_HTTP_ACCEPT', 'SOS_WORK_DISPATCHER', 'SP_SERVER_DIAGNOSTICS_SLEEP',
+		'SQLTRACE_BUFFER_FLUSH', 'SQLTRACE_INCREMENTAL_FLUSH_SLEEP', 'SQLTRACE_WAIT_ENTRIES',
+		'WAIT_FOR_RESULTS', 'WAITFOR', 'WAITFOR_TASKSHUTDOWN', 'WAIT_XTP_RECOVERY',
+		'WAIT_XTP_HOST_WAIT', 'WAIT_XTP_OFFLINE_CKPT_NEW_LOG', 'WAIT_XTP_CKPT_CLOSE',
+		'XE_DISPATCHER_JOIN', 'XE_DISPATCHER_WAIT', 'XE_TIMER_EVENT'
+	)
+`
+
+var fields = mssql.Field{
+	Name:        "waits",
+	Type:        "group",
+	Description: "`waits` contains the wait statistics of a wait type.",
+	Fields: []mssql.Field{
+		{Name: "type", Type: "keyword", Description: "Name of the wait type."},
+		{Name: "waiting_tasks.count", Type: "long", Description: "Number of waits on this wait type."},
+		{Name: "wait_time.ms", Type: "long", Description: "Total wait time on this wait type, including the signal wait time."},
+		{Name: "wait_time.max.ms", Type: "long", Description: "Maximum wait time on this wait type."},
+		{Name: "signal_wait_time.ms", Type: "long", Description: "Time between the signaling of waiting threads and their start."},
+		{Name: "interval.waiting_tasks.count", Type: "long", Description: "Number of waits since the previous fetch."},
+		{Name: "interval.wait_time.ms", Type: "long", Description: "Wait time since the previous fetch."},
+		{Name: "interval.signal_wait_time.ms", Type: "long", Description: "Signal wait time since the previous fetch."},
+	},
+}
+
+type waitStats struct {
+	waitingTasks   int64
+	waitTimeMs     int64
+	maxWaitTimeMs  int64
+	signalWaitTime int64
+}
+
+// MetricSet reports the cumulative and interval wait statistics per wait type.
+type MetricSet struct {
+	*mssql.MetricSet
+	deltas *mssql.Deltas
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	ms, err := mssql.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricSet{MetricSet: ms, deltas: mssql.NewDeltas()}, nil
+}
+
+// Fetch reports one event per wait type that has accumulated wait time.
+func (m *MetricSet) Fetch(r mb.ReporterV2) {
+	m.MetricSet.Fetch(r, m.fetch)
+}
+
+// NeedsBaseline is true until the statistics needed to compute the interval
+// values were fetched once.
+func (m *MetricSet) NeedsBaseline() bool {
+	return !m.deltas.Baseline()
+}
+
+func (m *MetricSet) fetch(ctx context.Context, r mb.ReporterV2) error {
+	stats, err := m.queryWaitStats(ctx)
+	if err != nil {
+		return err
+	}
+
+	if m.PrometheusEnabled() {
+		m.Publish(samples(stats))
+	}
+
+	events := make([]common.MapStr, 0, len(stats))
+	for waitType, s := range stats {
+		events = append(events, eventFields(waitType, s, m.deltas))
+	}
+	m.deltas.Next()
+
+	for _, fields := range events {
+		if !r.Event(mb.Event{MetricSetFields: fields}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func eventFields(waitType string, s waitStats, deltas *mssql.Deltas) common.MapStr {
+	fields := common.MapStr{
+		"type": waitType,
+		"waiting_tasks": common.MapStr{
+			"count": s.waitingTasks,
+		},
+		"wait_time": common.MapStr{
+			"ms":     s.waitTimeMs,
+			"max.ms": s.maxWaitTimeMs,
+		},
+		"signal_wait_time": common.MapStr{
+			"ms": s.signalWaitTime,
+		},
+	}
+
+	// Statistics are cleared on restart or with DBCC SQLPERF, in which
+	// case there is no meaningful interval value.
+	if delta, ok := deltas.Delta(waitType, s.waitingTasks, s.waitTimeMs, s.signalWaitTime); ok {
+		fields.Put("interval.waiting_tasks.count", delta[0])
+		fields.Put("interval.wait_time.ms", delta[1])
+		fields.Put("interval.signal_wait_time.ms", delta[2])
+	}
+	return fields
+}
+
+// samples exposes the cumulative wait statistics to Prometheus, labeled by
+// wait type.
+func samples(stats map[string]waitStats) []prometheus.Sample {
+	samples := make([]prometheus.Sample, 0, 3*len(stats))
+	for waitType, s := range stats {
+		labels := map[string]string{"wait_type": waitType}
+		samples = append(samples,
+			prometheus.Sample{
+				Name:   "mssql_waiting_tasks",
+				Help:   "Number of waits on the wait type.",
+				Type:   prometheus.Counter,
+				Labels: labels,
+				Value:  float64(s.waitingTasks),
+			},
+			prometheus.Sample{
+				Name:   "mssql_wait_time_seconds",
+				Help:   "Wait time on the wait type, including the signal wait time.",
+				Type:   prometheus.Counter,
+				Labels: labels,
+				Value:  float64(s.waitTimeMs) / 1000,
+			},
+			prometheus.Sample{
+				Name:   "mssql_signal_wait_time_seconds",
+				Help:   "Time between the signaling of waiting threads and their start.",
+				Type:   prometheus.Counter,
+				Labels: labels,
+				Value:  float64(s.signalWaitTime) / 1000,
+			},
+		)
+	}
+	return samples
+}
+
+func (m *MetricSet) queryWaitStats(ctx context.Context) (map[string]waitStats, error) {
+	stats := map[string]waitStats{}
+	err := m.Query(ctx, query, func(rows mssql.Rows) error {
+		var waitType string
+		var s waitStats
+		if err := rows.Scan(&waitType, &s.waitingTasks, &s.waitTimeMs, &s.maxWaitTimeMs, &s.signalWaitTime); err != nil {
+			return err
+		}
+		stats[waitType] = s
+		return nil
+	})
+
+	return stats, err
+}
@@ -0,0 +1,22 @@
+// +build !integration
+
+package waits
+
+import (
+	"testing"
+
+	"github.com/mathenning/mssqlbeat/module/mssql"
+)
+
+func TestIntervalAfterClear(t *testing.T) {
+	deltas := mssql.NewDeltas()
+	eventFields("LCK_M_X", waitStats{waitingTasks: 500, waitTimeMs: 1000, signalWaitTime: 40}, deltas)
+	deltas.Next()
+
+	// Cleared with DBCC SQLPERF, then a few long waits exceed the previous
+	// wait time while the task count is lower.
+	fields := eventFields("LCK_M_X", waitStats{waitingTasks: 3, waitTimeMs: 1500, signalWaitTime: 2}, deltas)
+	if _, err := fields.GetValue("interval"); err == nil {
+		t.Errorf("expected no interval values after the statistics were cleared, got %v", fields)
+	}
+}
@@ -0,0 +1,193 @@
+// Package prometheus exposes the latest values collected by mssqlbeat in the
+// Prometheus text exposition format, so they can be scraped in addition to
+// being published to the beat outputs.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Metric types.
+const (
+	Counter = "counter"
+	Gauge   = "gauge"
+)
+
+// Sample is a value of a metric family.
+type Sample struct {
+	Name   string // Family name, counters get the _total suffix on exposition.
+	Help   string
+	Type   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Registry holds the latest samples of every source. Each fetch of a
+// metricset replaces the samples of its source, so values that disappear from
+// the server also disappear from the exposition.
+type Registry struct {
+	mu      sync.RWMutex
+	enabled bool
+	sources map[string][]Sample
+}
+
+// Default is the registry the metricsets publish to and the listener exposes.
+var Default = NewRegistry()
+
+// NewRegistry creates an empty, disabled registry.
+func NewRegistry() *Registry {
+	return &Registry{sources: map[string][]Sample{}}
+}
+
+// Enabled tells whether the samples are exposed. Sources do not need to
+// build samples when they are not.
+func (r *Registry) Enabled() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.enabled
+}
+
+// SetEnabled enables or disables the registry. Disabling it drops all the
+// samples.
+func (r *Registry) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enabled = enabled
+	if !enabled {
+		r.sources = map[string][]Sample{}
+	}
+}
+
+// Set replaces the samples of a source.
+func (r *Registry) Set(source string, samples []Sample) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.enabled {
+		r.sources[source] = samples
+	}
+}
+
+// Remove drops the samples of a source.
+func (r *Registry) Remove(source string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.sources, source)
+}
+
+// Write writes all the samples grouped by metric family. With openMetrics
+// the output follows the OpenMetrics text format, otherwise the Prometheus
+// text format 0.0.4.
+func (r *Registry) Write(w io.Writer, openMetrics bool) error {
+	r.mu.RLock()
+	families := map[string][]Sample{}
+	for _, samples := range r.sources {
+		for _, s := range samples {
+			families[s.Name] = append(families[s.Name], s)
+		}
+	}
+	r.mu.RUnlock()
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		writeFamily(bw, families[name], openMetrics)
+	}
+	if openMetrics {
+		bw.WriteString("# EOF\n")
+	}
+	return bw.Flush()
+}
+
+func writeFamily(w *bufio.Writer, samples []Sample, openMetrics bool) {
+	// The first sample defines the family, samples of another type would
+	// make the exposition invalid.
+	first := samples[0]
+	name := MetricName(first.Name)
+	sampleName := name
+	if first.Type == Counter {
+		sampleName += "_total"
+		if !openMetrics {
+			name = sampleName
+		}
+	}
+
+	fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(first.Help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, first.Type)
+
+	lines := make([]string, 0, len(samples))
+	for _, s := range samples {
+		if s.Type != first.Type {
+			continue
+		}
+		lines = append(lines, sampleName+formatLabels(s.Labels)+" "+formatValue(s.Value))
+	}
+	sort.Strings(lines)
+	for _, line := range lines {
+		w.WriteString(line + "\n")
+	}
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]+`)
+
+// MetricName turns a name into a valid metric or label name.
+func MetricName(name string) string {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(name, "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = fmt.Sprintf("%s=\"%s\"", MetricName(name), escapeLabelValue(labels[name]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var (
+	helpEscaper       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string       { return helpEscaper.Replace(s) }
+func escapeLabelValue(s string) string { return labelValueEscaper.Replace(s) }
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return fmt.Sprintf("%g", v)
+}
@@ -0,0 +1,72 @@
+// +build !integration
+
+package prometheus
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	r.Set("ignored", []Sample{{Name: "mssql_up", Type: Gauge, Value: 1}})
+	r.SetEnabled(true)
+
+	r.Set("waits", []Sample{
+		{Name: "mssql_wait_time_seconds", Help: "Wait time.", Type: Counter, Labels: map[string]string{"wait_type": "PAGEIOLATCH_SH", "server": "sql1"}, Value: 1.5},
+		{Name: "mssql_wait_time_seconds", Help: "Wait time.", Type: Counter, Labels: map[string]string{"wait_type": "LCK_M_X", "server": "sql1"}, Value: 2},
+	})
+	r.Set("availability", []Sample{
+		{Name: "mssql_up", Help: "Up.\nOr not.", Type: Gauge, Labels: map[string]string{"server": `a"b\c`}, Value: 1},
+	})
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf, false); err != nil {
+		t.Fatal(err)
+	}
+	expected := `# HELP mssql_up Up.\nOr not.
+# TYPE mssql_up gauge
+mssql_up{server="a\"b\\c"} 1
+# HELP mssql_wait_time_seconds_total Wait time.
+# TYPE mssql_wait_time_seconds_total counter
+mssql_wait_time_seconds_total{server="sql1",wait_type="LCK_M_X"} 2
+mssql_wait_time_seconds_total{server="sql1",wait_type="PAGEIOLATCH_SH"} 1.5
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected text exposition:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	r.Remove("availability")
+	if err := r.Write(&buf, true); err != nil {
+		t.Fatal(err)
+	}
+	expected = `# HELP mssql_wait_time_seconds Wait time.
+# TYPE mssql_wait_time_seconds counter
+mssql_wait_time_seconds_total{server="sql1",wait_type="LCK_M_X"} 2
+mssql_wait_time_seconds_total{server="sql1",wait_type="PAGEIOLATCH_SH"} 1.5
+# EOF
+`
+	if buf.String() != expected {
+		t.Errorf("unexpected OpenMetrics exposition:\n%s", buf.String())
+	}
+
+	r.SetEnabled(false)
+	buf.Reset()
+	r.Write(&buf, false)
+	if buf.Len() != 0 {
+		t.Errorf("disabled registry should be empty, got:\n%s", buf.String())
+	}
+}
+
+func TestMetricName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"mssql_batch_requests": "mssql_batch_requests",
+		"mssql_cpu-usage %":    "mssql_cpu_usage",
+		"1st":                  "_1st",
+	} {
+		if got := MetricName(name); got != expected {
+			t.Errorf("MetricName(%q) = %q, expected %q", name, got, expected)
+		}
+	}
+}
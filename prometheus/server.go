@@ -0,0 +1,89 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// Config of the exposition listener.
+type Config struct {
+	Enabled bool   `config:"enabled"`
+	Host    string `config:"host"`
+	Port    int    `config:"port" validate:"min=0,max=65535"`
+	Path    string `config:"path"`
+}
+
+// DefaultConfig is the default configuration of the listener.
+var DefaultConfig = Config{
+	Host: "localhost",
+	Port: 9399,
+	Path: "/metrics",
+}
+
+const (
+	textContentType        = "text/plain; version=0.0.4; charset=utf-8"
+	openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Server exposes a registry over HTTP.
+type Server struct {
+	registry *Registry
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewServer listens on the configured address. The registry is enabled, so
+// the metricsets start publishing their samples to it.
+func NewServer(config Config, registry *Registry) (*Server, error) {
+	addr := net.JoinHostPort(config.Host, fmt.Sprint(config.Port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+
+	s := &Server{registry: registry, listener: listener}
+	mux := http.NewServeMux()
+	mux.HandleFunc(config.Path, s.handle)
+	s.server = &http.Server{Handler: mux}
+
+	registry.SetEnabled(true)
+	return s, nil
+}
+
+// Start serves the requests in the background.
+func (s *Server) Start() {
+	logp.Info("Exposing metrics in the Prometheus format on http://%s", s.listener.Addr())
+	go func() {
+		if err := s.server.Serve(s.listener); err != nil && err != http.ErrServerClosed {
+			logp.Err("Prometheus listener stopped: %v", err)
+		}
+	}()
+}
+
+// Stop closes the listener and disables the registry.
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	s.server.Shutdown(ctx)
+	s.registry.SetEnabled(false)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	openMetrics := strings.Contains(r.Header.Get("Accept"), "application/openmetrics-text")
+	if openMetrics {
+		w.Header().Set("Content-Type", openMetricsContentType)
+	} else {
+		w.Header().Set("Content-Type", textContentType)
+	}
+
+	if err := s.registry.Write(w, openMetrics); err != nil {
+		logp.Debug("prometheus", "Failed to write the exposition: %v", err)
+	}
+}